@@ -0,0 +1,190 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 原始回调归档实现
+ */
+
+package callback
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	defaultArchiveDir     = "callback_archive"
+	defaultArchivePrefix  = "callback"
+	defaultArchiveMaxSize = 100 << 20
+
+	archiveFileExt        = ".jsonl"
+	archiveFileTimeLayout = "20060102T150405.000000000"
+	archiveDayLayout      = "20060102"
+
+	ArchiveBodyJSON = "json" // 请求体为JSON，原样归档
+	ArchiveBodyRaw  = "raw"  // 请求体非JSON，以JSON字符串形式归档
+)
+
+type (
+	// ArchiveRecord 回调归档记录
+	ArchiveRecord struct {
+		Command      string          `json:"command"`                 // 回调命令
+		Query        string          `json:"query"`                   // 原始查询参数
+		Body         json.RawMessage `json:"body"`                    // 原始请求体
+		BodyEncoding string          `json:"body_encoding,omitempty"` // 请求体的归档方式，ArchiveBodyJSON 或 ArchiveBodyRaw
+		ReceivedAt   int64           `json:"received_at"`             // 接收时间，单位为毫秒
+		Ack          json.RawMessage `json:"ack,omitempty"`           // 已发送的应答
+	}
+
+	// Archiver 原始回调归档器
+	Archiver interface {
+		// Archive 归档回调记录
+		Archive(record *ArchiveRecord) error
+	}
+
+	// ArchiveOptions 文件归档配置
+	ArchiveOptions struct {
+		Dir     string // （选填）归档目录，默认为 callback_archive
+		Prefix  string // （选填）归档文件名前缀，默认为 callback
+		MaxSize int64  // （选填）单个归档文件的最大字节数，超过后切换至新文件，默认为100MB
+	}
+
+	// FileArchiver 以JSON Lines格式写入本地文件的归档器，按大小及自然日（UTC）切换文件
+	FileArchiver struct {
+		opt  ArchiveOptions
+		mu   sync.Mutex
+		file *os.File
+		size int64
+		day  string
+	}
+
+	// archiveWriter 记录应答内容的 http.ResponseWriter
+	archiveWriter struct {
+		http.ResponseWriter
+		buf bytes.Buffer
+	}
+)
+
+// NewFileArchiver 新建文件归档器
+// 归档文件命名为 {Prefix}-{创建时间}.jsonl，按文件名排序即为时间顺序
+func NewFileArchiver(opt ArchiveOptions) (*FileArchiver, error) {
+	if opt.Dir == "" {
+		opt.Dir = defaultArchiveDir
+	}
+	if opt.Prefix == "" {
+		opt.Prefix = defaultArchivePrefix
+	}
+	if opt.MaxSize <= 0 {
+		opt.MaxSize = defaultArchiveMaxSize
+	}
+
+	if err := os.MkdirAll(opt.Dir, 0755); err != nil {
+		return nil, err
+	}
+
+	return &FileArchiver{opt: opt}, nil
+}
+
+// Archive 归档回调记录
+func (a *FileArchiver) Archive(record *ArchiveRecord) error {
+	b, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	b = append(b, '\n')
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	now := time.Now().UTC()
+	day := now.Format(archiveDayLayout)
+	if a.file == nil || a.day != day || (a.size > 0 && a.size+int64(len(b)) > a.opt.MaxSize) {
+		if err = a.rotate(now); err != nil {
+			return err
+		}
+	}
+
+	n, err := a.file.Write(b)
+	a.size += int64(n)
+
+	return err
+}
+
+// Close 关闭当前归档文件
+func (a *FileArchiver) Close() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.file == nil {
+		return nil
+	}
+
+	err := a.file.Close()
+	a.file = nil
+
+	return err
+}
+
+// rotate 切换归档文件
+func (a *FileArchiver) rotate(now time.Time) error {
+	if a.file != nil {
+		if err := a.file.Close(); err != nil {
+			return err
+		}
+		a.file = nil
+	}
+
+	name := fmt.Sprintf("%s-%s%s", a.opt.Prefix, now.Format(archiveFileTimeLayout), archiveFileExt)
+	file, err := os.OpenFile(filepath.Join(a.opt.Dir, name), os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	a.file, a.size, a.day = file, 0, now.Format(archiveDayLayout)
+
+	return nil
+}
+
+// Write 写入应答并记录应答内容
+func (w *archiveWriter) Write(b []byte) (int, error) {
+	w.buf.Write(b)
+	return w.ResponseWriter.Write(b)
+}
+
+// archive 归档原始回调
+func (c *callback) archive(w *archiveWriter, command, query string, body []byte, receivedAt time.Time) {
+	record := &ArchiveRecord{
+		Command:      command,
+		Query:        query,
+		Body:         body,
+		BodyEncoding: ArchiveBodyJSON,
+		ReceivedAt:   receivedAt.UnixMilli(),
+	}
+
+	// 非JSON请求体以字符串形式归档，回放时还原
+	if !json.Valid(body) {
+		record.Body, _ = json.Marshal(string(body))
+		record.BodyEncoding = ArchiveBodyRaw
+	}
+
+	if ack := bytes.TrimSpace(w.buf.Bytes()); json.Valid(ack) {
+		record.Ack = ack
+	}
+
+	if err := c.archiver.Archive(record); err != nil {
+		c.logger.Error(context.Background(), "Failed to archive callback", map[string]interface{}{
+			"command": command,
+			"error":   err.Error(),
+		})
+
+		if c.onArchiveErr != nil {
+			c.onArchiveErr(record, err)
+		}
+	}
+}
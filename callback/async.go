@@ -0,0 +1,407 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 回调事件异步处理实现
+ */
+
+package callback
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/d60-Lab/tencent-im/internal/core"
+)
+
+const (
+	defaultQueueDir          = "callback_queue"
+	defaultAsyncWorkers      = 4
+	defaultAsyncMaxAttempts  = 5
+	defaultAsyncBackoff      = time.Second
+	defaultAsyncMaxBackoff   = time.Minute
+	defaultAsyncPollInterval = 500 * time.Millisecond
+)
+
+var (
+	errAsyncAlreadyStarted = errors.New("callback async dispatch has already been started")
+	errAsyncNotStarted     = errors.New("callback async dispatch is not started")
+)
+
+// DefaultAsyncEvents 默认异步处理的事件
+var DefaultAsyncEvents = []Event{
+	EventStateChange,
+	EventAfterPrivateMessageSend,
+	EventAfterGroupMessageSend,
+}
+
+type (
+	// AsyncOptions 异步处理配置
+	AsyncOptions struct {
+		Events       []Event       // （选填）异步处理的事件，默认为 DefaultAsyncEvents，发生之前回调不支持异步处理
+		Queue        Queue         // （选填）事件队列，默认使用基于本地文件的队列
+		Dir          string        // （选填）默认文件队列的存储目录，默认为 callback_queue
+		Workers      int           // （选填）并发处理的协程数，默认为4
+		MaxAttempts  int           // （选填）单个事件最大处理次数，超过后移入死信列表，默认为5
+		Backoff      time.Duration // （选填）首次重试的等待时间，之后每次重试翻倍，默认为1秒
+		MaxBackoff   time.Duration // （选填）重试的最大等待时间，默认为1分钟
+		PollInterval time.Duration // （选填）队列为空时的轮询间隔，默认为500毫秒
+	}
+
+	// asyncDispatcher 异步事件分发器
+	asyncDispatcher struct {
+		opt    AsyncOptions
+		queue  Queue
+		events map[Event]bool
+		notify chan struct{}
+		stop   chan struct{}
+		wg     sync.WaitGroup
+	}
+
+	// asyncAck 异步处理时的应答器，仅记录处理结果
+	asyncAck struct {
+		mu      sync.Mutex
+		acked   bool
+		failed  bool
+		message string
+	}
+)
+
+// StartAsync 开启异步处理
+// 开启后，指定事件在持久化至队列后立即应答成功，并由后台协程调用已注册的处理器进行处理
+// 处理器应答失败或发生 panic 时事件将按退避策略重试，超过最大处理次数后移入死信列表
+func (c *callback) StartAsync(opt ...AsyncOptions) error {
+	o := AsyncOptions{}
+	if len(opt) > 0 {
+		o = opt[0]
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.async != nil {
+		return errAsyncAlreadyStarted
+	}
+
+	d, err := newAsyncDispatcher(o, c.logger)
+	if err != nil {
+		return err
+	}
+
+	c.async = d
+	d.start(c.process)
+
+	return nil
+}
+
+// StopAsync 停止异步处理，等待处理中的事件完成
+// 队列中未处理的事件将保留，再次开启异步处理后继续处理
+func (c *callback) StopAsync() {
+	c.mu.Lock()
+	d := c.async
+	c.async = nil
+	c.mu.Unlock()
+
+	if d != nil {
+		d.close()
+	}
+}
+
+// DeadLetters 获取异步处理的死信列表
+func (c *callback) DeadLetters() ([]*QueueItem, error) {
+	c.mu.RLock()
+	d := c.async
+	c.mu.RUnlock()
+
+	if d == nil {
+		return nil, errAsyncNotStarted
+	}
+
+	return d.queue.DeadLetters()
+}
+
+// enqueue 异步处理事件，事件未开启异步处理时返回 false
+// 入队失败时返回错误，由调用方应答失败以便腾讯云IM后台重试，不会退化为同步处理
+func (c *callback) enqueue(event Event, command, query string, body []byte) (bool, error) {
+	c.mu.RLock()
+	d := c.async
+	c.mu.RUnlock()
+
+	if d == nil || !d.events[event] {
+		return false, nil
+	}
+
+	item := newQueueItem(command, body)
+	item.Query = query
+
+	if err := d.push(item); err != nil {
+		c.logger.Error(context.Background(), "Failed to enqueue callback event", map[string]interface{}{
+			"event":   int(event),
+			"command": command,
+			"error":   err.Error(),
+		})
+		return true, err
+	}
+
+	return true, nil
+}
+
+// process 处理队列中的事件
+func (c *callback) process(item *QueueItem) error {
+	return c.handleDetached(context.Background(), item.Command, item.Query, item.Body, false)
+}
+
+// handleDetached 脱离HTTP请求处理事件，应答仅用于记录处理结果
+func (c *callback) handleDetached(ctx context.Context, command, rawQuery string, body []byte, replay bool) (err error) {
+	event, data, err := c.parseCommand(command, body)
+	if err != nil {
+		if err == errInvalidCallbackCommand {
+			event, data = EventUnknown, &UnknownEvent{CallbackCommand: command, Body: body}
+		} else {
+			return err
+		}
+	}
+
+	query, _ := url.ParseQuery(rawQuery)
+	info := newRequestInfo(query, nil)
+	info.Replay = replay
+	ctx = WithRequestInfo(ctx, info)
+	a := &asyncAck{}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("callback handler panic: %v", r)
+		}
+	}()
+
+	c.chain()(ctx, event, a, data)
+
+	return a.err()
+}
+
+func newAsyncDispatcher(opt AsyncOptions, logger core.Logger) (*asyncDispatcher, error) {
+	if len(opt.Events) == 0 {
+		opt.Events = DefaultAsyncEvents
+	}
+	if opt.Dir == "" {
+		opt.Dir = defaultQueueDir
+	}
+	if opt.Workers <= 0 {
+		opt.Workers = defaultAsyncWorkers
+	}
+	if opt.MaxAttempts <= 0 {
+		opt.MaxAttempts = defaultAsyncMaxAttempts
+	}
+	if opt.Backoff <= 0 {
+		opt.Backoff = defaultAsyncBackoff
+	}
+	if opt.MaxBackoff <= 0 {
+		opt.MaxBackoff = defaultAsyncMaxBackoff
+	}
+	if opt.PollInterval <= 0 {
+		opt.PollInterval = defaultAsyncPollInterval
+	}
+
+	if opt.Queue == nil {
+		q, err := NewFileQueue(opt.Dir, logger)
+		if err != nil {
+			return nil, err
+		}
+		opt.Queue = q
+	}
+
+	d := &asyncDispatcher{
+		opt:    opt,
+		queue:  opt.Queue,
+		events: make(map[Event]bool, len(opt.Events)),
+		notify: make(chan struct{}, 1),
+		stop:   make(chan struct{}),
+	}
+
+	for _, event := range opt.Events {
+		if !event.IsBefore() {
+			d.events[event] = true
+		}
+	}
+
+	return d, nil
+}
+
+// start 启动处理协程
+func (d *asyncDispatcher) start(fn func(item *QueueItem) error) {
+	for i := 0; i < d.opt.Workers; i++ {
+		d.wg.Add(1)
+		go d.work(fn)
+	}
+}
+
+// close 停止处理协程
+func (d *asyncDispatcher) close() {
+	close(d.stop)
+	d.wg.Wait()
+}
+
+// push 事件入队并唤醒处理协程
+func (d *asyncDispatcher) push(item *QueueItem) error {
+	if err := d.queue.Push(item); err != nil {
+		return err
+	}
+
+	select {
+	case d.notify <- struct{}{}:
+	default:
+	}
+
+	return nil
+}
+
+// work 循环处理队列中的事件
+func (d *asyncDispatcher) work(fn func(item *QueueItem) error) {
+	defer d.wg.Done()
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-d.stop:
+			return
+		default:
+		}
+
+		item, err := d.queue.Pop()
+		if err == nil && item != nil {
+			d.handle(item, fn)
+			continue
+		}
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(d.opt.PollInterval)
+
+		select {
+		case <-d.stop:
+			return
+		case <-d.notify:
+		case <-timer.C:
+		}
+	}
+}
+
+// handle 处理单个事件
+func (d *asyncDispatcher) handle(item *QueueItem, fn func(item *QueueItem) error) {
+	item.Attempts++
+
+	err := fn(item)
+	if err == nil {
+		_ = d.queue.Done(item)
+		return
+	}
+
+	item.LastError = err.Error()
+
+	if item.Attempts >= d.opt.MaxAttempts {
+		_ = d.queue.Bury(item)
+		return
+	}
+
+	item.NextAttemptAt = time.Now().Add(d.backoff(item.Attempts)).UnixNano() / int64(time.Millisecond)
+	_ = d.queue.Retry(item)
+}
+
+// backoff 计算第 attempts 次处理失败后的重试等待时间
+func (d *asyncDispatcher) backoff(attempts int) time.Duration {
+	backoff := d.opt.Backoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= d.opt.MaxBackoff {
+			return d.opt.MaxBackoff
+		}
+	}
+
+	return backoff
+}
+
+// Ack 应答（同一回调仅首次应答生效）
+func (a *asyncAck) Ack(resp interface{}) error {
+	if r, ok := resp.(BaseResp); ok {
+		return a.record(r)
+	}
+	if r, ok := resp.(*BaseResp); ok {
+		return a.record(*r)
+	}
+
+	// 自定义应答（如 *BeforeGroupMessageSendResp）从序列化结果中解析基础应答信息
+	b, err := json.Marshal(resp)
+	if err != nil {
+		return err
+	}
+
+	var r BaseResp
+	if err = json.Unmarshal(b, &r); err != nil {
+		return err
+	}
+
+	return a.record(r)
+}
+
+// AckFailure 失败应答
+func (a *asyncAck) AckFailure(message ...string) error {
+	resp := BaseResp{ActionStatus: ackFailureStatus, ErrorCode: ackFailureCode}
+	if len(message) > 0 {
+		resp.ErrorInfo = message[0]
+	}
+
+	return a.record(resp)
+}
+
+// AckSuccess 成功应答
+func (a *asyncAck) AckSuccess(code int, message ...string) error {
+	resp := BaseResp{ActionStatus: ackSuccessStatus, ErrorCode: code}
+	if len(message) > 0 {
+		resp.ErrorInfo = message[0]
+	}
+
+	return a.record(resp)
+}
+
+// record 记录处理结果
+func (a *asyncAck) record(resp BaseResp) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.acked {
+		return errAlreadyAcked
+	}
+	a.acked = true
+
+	if resp.ActionStatus == ackFailureStatus {
+		a.failed, a.message = true, resp.ErrorInfo
+	}
+
+	return nil
+}
+
+// err 获取处理结果
+func (a *asyncAck) err() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if !a.failed {
+		return nil
+	}
+
+	if a.message == "" {
+		return errors.New("callback handler acked failure")
+	}
+
+	return errors.New(a.message)
+}
@@ -0,0 +1,229 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 回调事件异步处理单元测试
+ */
+
+package callback
+
+import (
+	"errors"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/d60-Lab/tencent-im/internal/core"
+)
+
+// failingQueue 入队总是失败的队列
+type failingQueue struct {
+	Queue
+}
+
+func (q failingQueue) Push(item *QueueItem) error {
+	return errors.New("disk full")
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if cond() {
+			return
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	t.Fatal("condition was not met before deadline")
+}
+
+func TestFileQueue(t *testing.T) {
+	q, err := NewFileQueue(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileQueue() error = %v", err)
+	}
+
+	first := newQueueItem(commandAfterGroupMessageSend, []byte(`{"GroupId":"1"}`))
+	second := newQueueItem(commandAfterGroupMessageSend, []byte(`{"GroupId":"2"}`))
+	for _, item := range []*QueueItem{first, second} {
+		if err = q.Push(item); err != nil {
+			t.Fatalf("Push() error = %v", err)
+		}
+	}
+
+	item, err := q.Pop()
+	if err != nil || item == nil || item.Id != first.Id {
+		t.Fatalf("Pop() = %v, %v, want first item", item, err)
+	}
+
+	item.NextAttemptAt = time.Now().Add(time.Hour).UnixNano() / int64(time.Millisecond)
+	if err = q.Retry(item); err != nil {
+		t.Fatalf("Retry() error = %v", err)
+	}
+
+	item, err = q.Pop()
+	if err != nil || item == nil || item.Id != second.Id {
+		t.Fatalf("Pop() = %v, %v, want second item while first is backing off", item, err)
+	}
+
+	if item, err = q.Pop(); err != nil || item != nil {
+		t.Fatalf("Pop() = %v, %v, want nil while second is in flight", item, err)
+	}
+
+	second.LastError = "failed"
+	if err = q.Bury(second); err != nil {
+		t.Fatalf("Bury() error = %v", err)
+	}
+
+	dead, err := q.DeadLetters()
+	if err != nil || len(dead) != 1 || dead[0].Id != second.Id || dead[0].LastError != "failed" {
+		t.Fatalf("DeadLetters() = %v, %v, want second item", dead, err)
+	}
+}
+
+func TestFileQueue_Corrupt(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "0000000000000000001-aaaaaa"+queueFileExt), []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	q, err := NewFileQueue(dir, core.NewNoopLogger())
+	if err != nil {
+		t.Fatalf("NewFileQueue() error = %v", err)
+	}
+
+	want := newQueueItem(commandAfterGroupMessageSend, []byte(`{"GroupId":"1"}`))
+	if err = q.Push(want); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+
+	item, err := q.Pop()
+	if err != nil || item == nil || item.Id != want.Id {
+		t.Fatalf("Pop() = %v, %v, want item after corrupt file", item, err)
+	}
+
+	if _, err = os.Stat(filepath.Join(dir, queueDeadSubDir, queueCorruptSubDir, "0000000000000000001-aaaaaa"+queueFileExt)); err != nil {
+		t.Errorf("corrupt file was not quarantined: %v", err)
+	}
+
+	if dead, err := q.DeadLetters(); err != nil || len(dead) != 0 {
+		t.Errorf("DeadLetters() = %v, %v, want none", dead, err)
+	}
+}
+
+func TestCallback_AsyncEnqueueFailure(t *testing.T) {
+	var calls int32
+
+	c := NewCallbackWithOptions(Options{SdkAppId: testAppId, Logger: core.NewNoopLogger()})
+	c.Register(EventAfterGroupMessageSend, func(ack Ack, data interface{}) {
+		atomic.AddInt32(&calls, 1)
+		_ = ack.AckSuccess(ackSuccessCode)
+	})
+
+	q, err := NewFileQueue(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileQueue() error = %v", err)
+	}
+
+	if err = c.StartAsync(AsyncOptions{Queue: failingQueue{q}, PollInterval: time.Hour}); err != nil {
+		t.Fatalf("StartAsync() error = %v", err)
+	}
+	defer c.StopAsync()
+
+	w := httptest.NewRecorder()
+	c.Listen(w, newTestRequest(commandAfterGroupMessageSend, `{"GroupId":"@TGS#1"}`))
+	if got := decodeTestResp(t, w); got.ActionStatus != ackFailureStatus || got.ErrorInfo != "disk full" {
+		t.Errorf("resp = %+v, want failure so that the callback is retried", got)
+	}
+
+	if n := atomic.LoadInt32(&calls); n != 0 {
+		t.Errorf("handler called %d times, want no synchronous fallback", n)
+	}
+}
+
+func TestCallback_Async(t *testing.T) {
+	var calls int32
+
+	c := NewCallback(testAppId)
+	c.Register(EventAfterGroupMessageSend, func(ack Ack, data interface{}) {
+		if atomic.AddInt32(&calls, 1) < 3 {
+			_ = ack.AckFailure("temporary failure")
+			return
+		}
+		_ = ack.AckSuccess(ackSuccessCode)
+	})
+	c.Register(EventAfterPrivateMessageSend, func(ack Ack, data interface{}) {
+		panic("boom")
+	})
+
+	if err := c.StartAsync(AsyncOptions{
+		Dir:          t.TempDir(),
+		Workers:      2,
+		MaxAttempts:  3,
+		Backoff:      time.Millisecond,
+		PollInterval: time.Millisecond,
+	}); err != nil {
+		t.Fatalf("StartAsync() error = %v", err)
+	}
+	defer c.StopAsync()
+
+	if err := c.StartAsync(); err != errAsyncAlreadyStarted {
+		t.Errorf("StartAsync() error = %v, want %v", err, errAsyncAlreadyStarted)
+	}
+
+	w := httptest.NewRecorder()
+	c.Listen(w, newTestRequest(commandAfterGroupMessageSend, `{"GroupId":"@TGS#1"}`))
+	if got := decodeTestResp(t, w); got.ActionStatus != ackSuccessStatus {
+		t.Errorf("ActionStatus = %v, want %v", got.ActionStatus, ackSuccessStatus)
+	}
+
+	c.Listen(httptest.NewRecorder(), newTestRequest(commandAfterPrivateMessageSend, `{"MsgKey":"1"}`))
+
+	waitFor(t, func() bool { return atomic.LoadInt32(&calls) == 3 })
+	waitFor(t, func() bool {
+		dead, err := c.DeadLetters()
+		return err == nil && len(dead) == 1 && dead[0].Command == commandAfterPrivateMessageSend && dead[0].Attempts == 3
+	})
+}
+
+func TestAsyncDispatcher_Backoff(t *testing.T) {
+	d := &asyncDispatcher{opt: AsyncOptions{Backoff: time.Second, MaxBackoff: 5 * time.Second}}
+
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Second},
+		{attempts: 2, want: 2 * time.Second},
+		{attempts: 3, want: 4 * time.Second},
+		{attempts: 4, want: 5 * time.Second},
+	}
+
+	for _, tt := range tests {
+		if got := d.backoff(tt.attempts); got != tt.want {
+			t.Errorf("backoff(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
+
+func TestAsyncAck(t *testing.T) {
+	a := &asyncAck{}
+
+	if err := a.Ack("not an object"); err == nil {
+		t.Error("Ack() error = nil, want unmarshal error")
+	}
+
+	if err := a.AckFailure("first"); err != nil {
+		t.Fatalf("AckFailure() error = %v", err)
+	}
+	if err := a.AckSuccess(ackSuccessCode); err != errAlreadyAcked {
+		t.Errorf("AckSuccess() error = %v, want %v", err, errAlreadyAcked)
+	}
+	if err := a.err(); err == nil || err.Error() != "first" {
+		t.Errorf("err() = %v, want first", err)
+	}
+}
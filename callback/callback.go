@@ -8,12 +8,18 @@
 package callback
 
 import (
+	"context"
+	"crypto/subtle"
 	"encoding/json"
 	"errors"
-	"io/ioutil"
+	"io"
 	"net/http"
 	"strconv"
+	"strings"
 	"sync"
+	"time"
+
+	"github.com/d60-Lab/tencent-im/internal/core"
 )
 
 const (
@@ -66,6 +72,34 @@ const (
 	EventAfterGroupInfoChanged
 )
 
+// EventUnknown 未知事件（SDK暂不支持的回调命令）
+const EventUnknown Event = 0
+
+var eventCommands = map[Event]string{
+	EventStateChange:               commandStateChange,
+	EventBeforeFriendAdd:           commandBeforeFriendAdd,
+	EventBeforeFriendResponse:      commandBeforeFriendResponse,
+	EventAfterFriendAdd:            commandAfterFriendAdd,
+	EventAfterFriendDelete:         commandAfterFriendDelete,
+	EventAfterBlacklistAdd:         commandAfterBlacklistAdd,
+	EventAfterBlacklistDelete:      commandAfterBlacklistDelete,
+	EventBeforePrivateMessageSend:  commandBeforePrivateMessageSend,
+	EventAfterPrivateMessageSend:   commandAfterPrivateMessageSend,
+	EventAfterPrivateMessageReport: commandAfterPrivateMessageReport,
+	EventAfterPrivateMessageRevoke: commandAfterPrivateMessageRevoke,
+	EventBeforeGroupCreate:         commandBeforeGroupCreate,
+	EventAfterGroupCreate:          commandAfterGroupCreate,
+	EventBeforeApplyJoinGroup:      commandBeforeApplyJoinGroup,
+	EventBeforeInviteJoinGroup:     commandBeforeInviteJoinGroup,
+	EventAfterNewMemberJoinGroup:   commandAfterNewMemberJoinGroup,
+	EventAfterMemberExitGroup:      commandAfterMemberExitGroup,
+	EventBeforeGroupMessageSend:    commandBeforeGroupMessageSend,
+	EventAfterGroupMessageSend:     commandAfterGroupMessageSend,
+	EventAfterGroupFull:            commandAfterGroupFull,
+	EventAfterGroupDestroyed:       commandAfterGroupDestroyed,
+	EventAfterGroupInfoChanged:     commandAfterGroupInfoChanged,
+}
+
 const (
 	ackSuccessStatus = "OK"
 	ackFailureStatus = "FAIL"
@@ -78,26 +112,77 @@ const (
 	queryClientId    = "ClientIP"
 	queryOptPlatform = "OptPlatform"
 	queryContentType = "contenttype"
+
+	defaultMaxBodySize = 1 << 20 // 默认请求体最大字节数（1MB）
+
+	ReplayHeader = "X-Callback-Replay" // 回放请求头，值需与 Options.ReplayToken 一致
+)
+
+var (
+	errInvalidCallbackCommand = errors.New("invalid callback command")
+	errAlreadyAcked           = errors.New("callback has already been acked")
+	errBodyTooLarge           = errors.New("request body too large")
 )
 
 type (
-	Event            int
-	EventHandlerFunc func(ack Ack, data interface{})
-	Options          struct {
-		SdkAppId int
+	Event                          int
+	EventHandlerFunc               func(ack Ack, data interface{})
+	ContextEventHandlerFunc        func(ctx context.Context, ack Ack, data interface{})
+	UnknownEventHandlerFunc        func(ack Ack, command string, body json.RawMessage)
+	UnknownEventContextHandlerFunc func(ctx context.Context, ack Ack, command string, body json.RawMessage)
+	HandlerFunc                    func(ctx context.Context, event Event, ack Ack, data interface{})
+	Middleware                     func(next HandlerFunc) HandlerFunc
+	Options                        struct {
+		SdkAppId       int                                    // 应用SDKAppID
+		MaxBodySize    int64                                  // （选填）请求体最大字节数，默认为1MB
+		Archiver       Archiver                               // （选填）原始回调归档器，归档失败不影响回调处理
+		Logger         core.Logger                            // （选填）记录入队失败、归档失败等内部错误的日志，默认使用标准输出
+		ReplayToken    string                                 // （选填）回放令牌，请求头 ReplayHeader 与之一致时按回放处理（不经过异步队列、归档及去重），为空时不识别回放请求
+		OnArchiveError func(record *ArchiveRecord, err error) // （选填）归档失败（如磁盘已满、无写入权限）时的回调，归档失败同时记录至 Logger
 	}
 
 	Callback interface {
+		http.Handler
 		// Register 注册事件
 		Register(event Event, handler EventHandlerFunc)
-		// Listen 监听事件
+		// RegisterContext 注册事件（处理器可通过上下文获取回调请求信息）
+		RegisterContext(event Event, handler ContextEventHandlerFunc)
+		// RegisterUnknown 注册未知事件处理器（SDK暂不支持的回调命令将交由该处理器处理）
+		RegisterUnknown(handler UnknownEventHandlerFunc)
+		// RegisterUnknownContext 注册未知事件处理器（处理器可通过上下文获取回调请求信息）
+		RegisterUnknownContext(handler UnknownEventContextHandlerFunc)
+		// Use 添加事件处理中间件（先添加的中间件位于调用链外层）
+		Use(middleware ...Middleware)
+		// StartAsync 开启异步处理（事件持久化至队列后立即应答，由后台协程处理）
+		StartAsync(opt ...AsyncOptions) error
+		// StopAsync 停止异步处理
+		StopAsync()
+		// DeadLetters 获取异步处理的死信列表
+		DeadLetters() ([]*QueueItem, error)
+		// Replay 回放已归档的回调（不经过异步队列及归档，应答将被丢弃）
+		Replay(ctx context.Context, opt ReplayOptions) (*ReplayResult, error)
+		// Listen 监听事件（等同于 ServeHTTP）
 		Listen(w http.ResponseWriter, r *http.Request)
 	}
 
 	callback struct {
-		appId    int
-		mu       sync.Mutex
-		handlers map[Event]EventHandlerFunc
+		appId          int
+		maxBodySize    int64
+		mu             sync.RWMutex
+		handlers       map[Event]ContextEventHandlerFunc
+		unknownHandler UnknownEventContextHandlerFunc
+		middlewares    []Middleware
+		async          *asyncDispatcher
+		archiver       Archiver
+		onArchiveErr   func(record *ArchiveRecord, err error)
+		logger         core.Logger
+		replayToken    string
+	}
+
+	// UnknownEvent 未知事件（SDK暂不支持的回调命令）
+	UnknownEvent struct {
+		CallbackCommand string          // 回调命令
+		Body            json.RawMessage // 原始回调数据
 	}
 
 	Ack interface {
@@ -110,26 +195,88 @@ type (
 	}
 
 	ack struct {
-		w http.ResponseWriter
+		mu    sync.Mutex
+		w     http.ResponseWriter
+		acked bool
 	}
 )
 
 func NewCallback(appId int) Callback {
+	return NewCallbackWithOptions(Options{SdkAppId: appId})
+}
+
+// NewCallbackWithOptions 按配置新建回调事件处理
+func NewCallbackWithOptions(opt Options) Callback {
+	if opt.MaxBodySize <= 0 {
+		opt.MaxBodySize = defaultMaxBodySize
+	}
+	if opt.Logger == nil {
+		opt.Logger = core.NewDefaultLogger()
+	}
+
 	return &callback{
-		appId:    appId,
-		handlers: make(map[Event]EventHandlerFunc),
+		appId:        opt.SdkAppId,
+		maxBodySize:  opt.MaxBodySize,
+		archiver:     opt.Archiver,
+		onArchiveErr: opt.OnArchiveError,
+		logger:       opt.Logger,
+		replayToken:  opt.ReplayToken,
+		handlers:     make(map[Event]ContextEventHandlerFunc),
 	}
 }
 
 // Register 注册事件
 func (c *callback) Register(event Event, handler EventHandlerFunc) {
+	c.RegisterContext(event, func(ctx context.Context, ack Ack, data interface{}) {
+		handler(ack, data)
+	})
+}
+
+// RegisterContext 注册事件
+// 处理器可通过 RequestInfoFromContext 获取回调请求信息
+func (c *callback) RegisterContext(event Event, handler ContextEventHandlerFunc) {
 	c.mu.Lock()
 	c.handlers[event] = handler
 	c.mu.Unlock()
 }
 
+// RegisterUnknown 注册未知事件处理器
+// 未注册时，SDK暂不支持的回调命令默认应答成功，以免腾讯云IM后台执行失败策略
+func (c *callback) RegisterUnknown(handler UnknownEventHandlerFunc) {
+	c.RegisterUnknownContext(func(ctx context.Context, ack Ack, command string, body json.RawMessage) {
+		handler(ack, command, body)
+	})
+}
+
+// RegisterUnknownContext 注册未知事件处理器
+// 处理器可通过 RequestInfoFromContext 获取回调请求信息
+func (c *callback) RegisterUnknownContext(handler UnknownEventContextHandlerFunc) {
+	c.mu.Lock()
+	c.unknownHandler = handler
+	c.mu.Unlock()
+}
+
+// Use 添加事件处理中间件
+func (c *callback) Use(middleware ...Middleware) {
+	c.mu.Lock()
+	c.middlewares = append(c.middlewares, middleware...)
+	c.mu.Unlock()
+}
+
 // Listen 监听事件
 func (c *callback) Listen(w http.ResponseWriter, r *http.Request) {
+	c.ServeHTTP(w, r)
+}
+
+// ServeHTTP 处理回调请求
+func (c *callback) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	receivedAt := time.Now()
+	replay := c.isReplay(r)
+	archive := c.archiver != nil && !replay
+	if archive {
+		w = &archiveWriter{ResponseWriter: w}
+	}
+
 	a := newAck(w)
 
 	appId, ok := c.GetQuery(r, queryAppId)
@@ -140,26 +287,96 @@ func (c *callback) Listen(w http.ResponseWriter, r *http.Request) {
 
 	command, ok := c.GetQuery(r, queryCommand)
 	if !ok {
-		_ = a.AckFailure("invalid callback command")
+		_ = a.AckFailure(errInvalidCallbackCommand.Error())
 		return
 	}
 
-	body, err := ioutil.ReadAll(r.Body)
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, c.maxBodySize))
 	_ = r.Body.Close()
 	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			err = errBodyTooLarge
+		}
 		_ = a.AckFailure(err.Error())
 		return
 	}
 
-	if event, data, err := c.parseCommand(command, body); err != nil {
-		_ = a.AckFailure(err.Error())
-	} else {
-		if fn, ok := c.handlers[event]; ok {
-			fn(a, data)
+	if archive {
+		defer c.archive(w.(*archiveWriter), command, r.URL.RawQuery, body, receivedAt)
+	}
+
+	info := newRequestInfo(r.URL.Query(), r)
+	info.Replay = replay
+	ctx := WithRequestInfo(r.Context(), info)
+
+	event, data, err := c.parseCommand(command, body)
+	if err != nil {
+		if err != errInvalidCallbackCommand {
+			_ = a.AckFailure(err.Error())
 			return
+		}
+		event, data = EventUnknown, &UnknownEvent{CallbackCommand: command, Body: body}
+	}
+
+	if !replay {
+		if queued, err := c.enqueue(event, command, r.URL.RawQuery, body); queued {
+			if err != nil {
+				_ = a.AckFailure(err.Error())
+			} else {
+				_ = a.AckSuccess(ackSuccessCode)
+			}
+			return
+		}
+	}
+
+	c.chain()(ctx, event, a, data)
+}
+
+// isReplay 判断是否为携带有效回放令牌的回放请求
+func (c *callback) isReplay(r *http.Request) bool {
+	if c.replayToken == "" {
+		return false
+	}
+
+	token := r.Header.Get(ReplayHeader)
+
+	return token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(c.replayToken)) == 1
+}
+
+// chain 构建中间件调用链
+func (c *callback) chain() HandlerFunc {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	h := c.dispatch
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		h = c.middlewares[i](h)
+	}
+
+	return h
+}
+
+// dispatch 分发事件至已注册的处理器
+func (c *callback) dispatch(ctx context.Context, event Event, a Ack, data interface{}) {
+	c.mu.RLock()
+	fn, ok := c.handlers[event]
+	unknownHandler := c.unknownHandler
+	c.mu.RUnlock()
+
+	if event == EventUnknown {
+		if u, isUnknown := data.(*UnknownEvent); isUnknown && unknownHandler != nil {
+			unknownHandler(ctx, a, u.CallbackCommand, u.Body)
 		} else {
 			_ = a.AckSuccess(ackSuccessCode)
 		}
+		return
+	}
+
+	if ok {
+		fn(ctx, a, data)
+	} else {
+		_ = a.AckSuccess(ackSuccessCode)
 	}
 }
 
@@ -233,7 +450,7 @@ func (c *callback) parseCommand(command string, body []byte) (event Event, data
 		event = EventAfterGroupInfoChanged
 		data = &AfterGroupInfoChanged{}
 	default:
-		return 0, nil, errors.New("invalid callback command")
+		return 0, nil, errInvalidCallbackCommand
 	}
 
 	if err = json.Unmarshal(body, &data); err != nil {
@@ -252,12 +469,45 @@ func (c *callback) GetQuery(r *http.Request, key string) (string, bool) {
 	}
 }
 
+// String 获取事件对应的回调命令
+func (e Event) String() string {
+	if command, ok := eventCommands[e]; ok {
+		return command
+	}
+
+	return "Unknown"
+}
+
+// IsBefore 是否为发生之前回调（需在腾讯云IM后台超时前应答）
+func (e Event) IsBefore() bool {
+	return strings.Contains(eventCommands[e], ".CallbackBefore") || strings.Contains(eventCommands[e], ".CallbackPrev")
+}
+
+// ParseEvent 根据回调命令获取事件，SDK暂不支持的回调命令返回 EventUnknown 及 false
+func ParseEvent(command string) (Event, bool) {
+	for event, c := range eventCommands {
+		if c == command {
+			return event, true
+		}
+	}
+
+	return EventUnknown, false
+}
+
 func newAck(w http.ResponseWriter) Ack {
-	return &ack{w}
+	return &ack{w: w}
 }
 
-// Ack 应答
+// Ack 应答（同一回调仅首次应答生效）
 func (a *ack) Ack(resp interface{}) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.acked {
+		return errAlreadyAcked
+	}
+	a.acked = true
+
 	b, _ := json.Marshal(resp)
 	a.w.WriteHeader(http.StatusOK)
 	_, err := a.w.Write(b)
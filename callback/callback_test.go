@@ -0,0 +1,229 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 回调事件处理单元测试
+ */
+
+package callback
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/d60-Lab/tencent-im/internal/types"
+)
+
+const testAppId = 1400000000
+
+func newTestRequest(command, body string) *http.Request {
+	url := "/callback?SdkAppid=1400000000&CallbackCommand=" + command + "&contenttype=json&ClientIP=127.0.0.1&OptPlatform=RESTAPI"
+	return httptest.NewRequest(http.MethodPost, url, strings.NewReader(body))
+}
+
+func decodeTestResp(t *testing.T, w *httptest.ResponseRecorder) BaseResp {
+	t.Helper()
+
+	resp := BaseResp{}
+	if err := json.Unmarshal(w.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("unmarshal ack failed: %v, body: %s", err, w.Body.String())
+	}
+
+	return resp
+}
+
+func TestCallback_Listen(t *testing.T) {
+	tests := []struct {
+		name       string
+		command    string
+		body       string
+		register   bool
+		wantStatus string
+	}{
+		{
+			name:       "known event without handler",
+			command:    commandAfterFriendAdd,
+			body:       `{"CallbackCommand":"Sns.CallbackFriendAdd"}`,
+			wantStatus: ackSuccessStatus,
+		},
+		{
+			name:       "known event with handler",
+			command:    commandAfterFriendAdd,
+			body:       `{"CallbackCommand":"Sns.CallbackFriendAdd"}`,
+			register:   true,
+			wantStatus: ackFailureStatus,
+		},
+		{
+			name:       "invalid body",
+			command:    commandAfterFriendAdd,
+			body:       `{`,
+			wantStatus: ackFailureStatus,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := NewCallback(testAppId)
+			if tt.register {
+				c.Register(EventAfterFriendAdd, func(ack Ack, data interface{}) {
+					if _, ok := data.(*AfterFriendAdd); !ok {
+						t.Errorf("data type = %T, want *AfterFriendAdd", data)
+					}
+					_ = ack.AckFailure("rejected")
+				})
+			}
+
+			w := httptest.NewRecorder()
+			c.Listen(w, newTestRequest(tt.command, tt.body))
+
+			if got := decodeTestResp(t, w); got.ActionStatus != tt.wantStatus {
+				t.Errorf("ActionStatus = %v, want %v", got.ActionStatus, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestCallback_ListenInvalidAppId(t *testing.T) {
+	c := NewCallback(1)
+	w := httptest.NewRecorder()
+	c.Listen(w, newTestRequest(commandAfterFriendAdd, `{}`))
+
+	if got := decodeTestResp(t, w); got.ActionStatus != ackFailureStatus {
+		t.Errorf("ActionStatus = %v, want %v", got.ActionStatus, ackFailureStatus)
+	}
+}
+
+func TestCallback_ListenUnknownCommand(t *testing.T) {
+	const (
+		command = "Group.CallbackSomethingNew"
+		body    = `{"CallbackCommand":"Group.CallbackSomethingNew","GroupId":"@TGS#1"}`
+	)
+
+	t.Run("default policy", func(t *testing.T) {
+		c := NewCallback(testAppId)
+		w := httptest.NewRecorder()
+		c.Listen(w, newTestRequest(command, body))
+
+		if got := decodeTestResp(t, w); got.ActionStatus != ackSuccessStatus || got.ErrorCode != ackSuccessCode {
+			t.Errorf("ack = %+v, want success", got)
+		}
+	})
+
+	t.Run("fallback handler", func(t *testing.T) {
+		var (
+			called     bool
+			gotCommand string
+			gotBody    json.RawMessage
+		)
+
+		c := NewCallback(testAppId)
+		c.RegisterUnknown(func(ack Ack, command string, body json.RawMessage) {
+			called, gotCommand, gotBody = true, command, body
+			_ = ack.AckFailure("unsupported")
+		})
+
+		w := httptest.NewRecorder()
+		c.Listen(w, newTestRequest(command, body))
+
+		if !called {
+			t.Fatal("unknown handler was not called")
+		}
+		if gotCommand != command {
+			t.Errorf("command = %v, want %v", gotCommand, command)
+		}
+		if string(gotBody) != body {
+			t.Errorf("body = %s, want %s", gotBody, body)
+		}
+		if got := decodeTestResp(t, w); got.ActionStatus != ackFailureStatus {
+			t.Errorf("ActionStatus = %v, want %v", got.ActionStatus, ackFailureStatus)
+		}
+	})
+
+	t.Run("context fallback handler", func(t *testing.T) {
+		var gotInfo *RequestInfo
+
+		c := NewCallback(testAppId)
+		c.RegisterUnknownContext(func(ctx context.Context, ack Ack, command string, body json.RawMessage) {
+			gotInfo, _ = RequestInfoFromContext(ctx)
+			_ = ack.AckSuccess(ackSuccessCode)
+		})
+
+		c.Listen(httptest.NewRecorder(), newTestRequest(command, body))
+
+		if gotInfo == nil || gotInfo.Command != command {
+			t.Errorf("request info = %+v, want command %v", gotInfo, command)
+		}
+	})
+}
+
+func TestCallback_ServeHTTP(t *testing.T) {
+	var info *RequestInfo
+
+	c := NewCallback(testAppId)
+	c.RegisterContext(EventAfterFriendAdd, func(ctx context.Context, ack Ack, data interface{}) {
+		info, _ = RequestInfoFromContext(ctx)
+		_ = ack.AckSuccess(ackSuccessCode)
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle("/callback", c)
+
+	w := httptest.NewRecorder()
+	mux.ServeHTTP(w, newTestRequest(commandAfterFriendAdd, `{}`))
+
+	if got := decodeTestResp(t, w); got.ActionStatus != ackSuccessStatus {
+		t.Errorf("ActionStatus = %v, want %v", got.ActionStatus, ackSuccessStatus)
+	}
+	if info == nil {
+		t.Fatal("request info is missing from context")
+	}
+	if info.ClientIP != "127.0.0.1" || info.OptPlatform != "RESTAPI" || info.ContentType != "json" {
+		t.Errorf("request info = %+v", info)
+	}
+	if info.Command != commandAfterFriendAdd || info.Request == nil {
+		t.Errorf("request info = %+v", info)
+	}
+}
+
+func TestCallback_MaxBodySize(t *testing.T) {
+	called := false
+
+	c := NewCallbackWithOptions(Options{SdkAppId: testAppId, MaxBodySize: 16})
+	c.Register(EventAfterFriendAdd, func(ack Ack, data interface{}) {
+		called = true
+	})
+
+	w := httptest.NewRecorder()
+	c.ServeHTTP(w, newTestRequest(commandAfterFriendAdd, `{"CallbackCommand":"Sns.CallbackFriendAdd"}`))
+
+	got := decodeTestResp(t, w)
+	if got.ActionStatus != ackFailureStatus || got.ErrorInfo != errBodyTooLarge.Error() {
+		t.Errorf("ack = %+v, want body too large failure", got)
+	}
+	if called {
+		t.Error("handler should not be called for oversized body")
+	}
+}
+
+func TestCallback_RelayMessage(t *testing.T) {
+	var got *types.MsgRelayContent
+
+	c := NewCallback(testAppId)
+	c.Register(EventBeforeGroupMessageSend, func(ack Ack, data interface{}) {
+		got, _ = data.(*BeforeGroupMessageSend).MsgBody[0].MsgContent.(*types.MsgRelayContent)
+		_ = ack.AckSuccess(ackSuccessCode)
+	})
+
+	body := `{"GroupId":"@TGS#1","MsgBody":[{"MsgType":"TIMRelayElem","MsgContent":{"Title":"chat history","MsgNum":1,"AbstractList":["user1: hi"],"MsgList":[{"From_Account":"user1","MsgTimeStamp":1700000000,"MsgBody":[{"MsgType":"TIMTextElem","MsgContent":{"Text":"hi"}}]}]}}]}`
+	c.Listen(httptest.NewRecorder(), newTestRequest(commandBeforeGroupMessageSend, body))
+
+	if got == nil || len(got.MsgList) != 1 || got.MsgList[0].FromUserId != "user1" {
+		t.Fatalf("relay content = %+v", got)
+	}
+	if text, ok := got.MsgList[0].MsgBody[0].MsgContent.(*types.MsgTextContent); !ok || text.Text != "hi" {
+		t.Errorf("relayed body = %#v", got.MsgList[0].MsgBody[0].MsgContent)
+	}
+}
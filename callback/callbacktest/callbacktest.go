@@ -0,0 +1,162 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 回调事件模拟器，用于在不依赖腾讯云IM后台的情况下测试回调处理器
+ */
+
+package callbacktest
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/d60-Lab/tencent-im/callback"
+)
+
+const (
+	defaultPath        = "/callback"
+	defaultClientIP    = "127.0.0.1"
+	defaultOptPlatform = "RESTAPI"
+	defaultContentType = "json"
+
+	ackSuccessStatus = "OK"
+)
+
+var errUnknownEvent = errors.New("callbacktest: unknown callback event")
+
+type (
+	// Options 模拟器配置
+	Options struct {
+		AppId       int    // （必填）应用SDKAppID
+		Token       string // （选填）回调鉴权Token，设置后请求将携带 Sign 及 RequestTime 参数
+		ClientIP    string // （选填）客户端IP地址，默认为127.0.0.1
+		OptPlatform string // （选填）客户端平台，默认为RESTAPI
+	}
+
+	// Simulator 回调事件模拟器
+	Simulator struct {
+		opt     Options
+		handler http.Handler
+	}
+
+	// Result 回调处理结果
+	Result struct {
+		StatusCode int               // HTTP状态码
+		Body       []byte            // 应答原始数据
+		Resp       callback.BaseResp // 应答基础信息
+	}
+)
+
+// NewSimulator 新建回调事件模拟器，handler 通常为 callback.Callback
+func NewSimulator(handler http.Handler, opt Options) *Simulator {
+	if opt.ClientIP == "" {
+		opt.ClientIP = defaultClientIP
+	}
+	if opt.OptPlatform == "" {
+		opt.OptPlatform = defaultOptPlatform
+	}
+
+	return &Simulator{opt: opt, handler: handler}
+}
+
+// Send 模拟发送回调事件
+// payload 为空时使用 DefaultPayload；为 map[string]interface{} 时覆盖默认数据中的同名字段；
+// 为 []byte、string 或 json.RawMessage 时作为原始请求体；其他类型（如 *callback.AfterGroupMessageSend）序列化后作为请求体
+func (s *Simulator) Send(event callback.Event, payload ...interface{}) (*Result, error) {
+	defaults := DefaultPayload(event)
+	if defaults == nil {
+		return nil, errUnknownEvent
+	}
+
+	var p interface{}
+	if len(payload) > 0 {
+		p = payload[0]
+	}
+
+	body, err := buildBody(defaults, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.SendCommand(event.String(), body)
+}
+
+// SendCommand 模拟发送任意回调命令（可用于测试SDK暂不支持的回调命令）
+func (s *Simulator) SendCommand(command string, body []byte) (*Result, error) {
+	w := httptest.NewRecorder()
+	s.handler.ServeHTTP(w, s.NewRequest(command, body))
+
+	ret := &Result{StatusCode: w.Code, Body: w.Body.Bytes()}
+	if err := json.Unmarshal(ret.Body, &ret.Resp); err != nil {
+		return ret, fmt.Errorf("callbacktest: invalid ack %q: %w", ret.Body, err)
+	}
+
+	return ret, nil
+}
+
+// NewRequest 构建回调请求
+func (s *Simulator) NewRequest(command string, body []byte) *http.Request {
+	query := url.Values{}
+	query.Set("SdkAppid", strconv.Itoa(s.opt.AppId))
+	query.Set("CallbackCommand", command)
+	query.Set("contenttype", defaultContentType)
+	query.Set("ClientIP", s.opt.ClientIP)
+	query.Set("OptPlatform", s.opt.OptPlatform)
+
+	if s.opt.Token != "" {
+		requestTime := strconv.FormatInt(time.Now().Unix(), 10)
+		query.Set("RequestTime", requestTime)
+		query.Set("Sign", Sign(s.opt.Token, requestTime))
+	}
+
+	r := httptest.NewRequest(http.MethodPost, defaultPath+"?"+query.Encode(), bytes.NewReader(body))
+	r.Header.Set("Content-Type", "application/json")
+
+	return r
+}
+
+// Sign 计算回调鉴权签名：sha256(Token + RequestTime)
+func Sign(token, requestTime string) string {
+	sum := sha256.Sum256([]byte(token + requestTime))
+	return hex.EncodeToString(sum[:])
+}
+
+// OK 是否应答成功
+func (r *Result) OK() bool {
+	return r.Resp.ActionStatus == ackSuccessStatus && r.Resp.ErrorCode == 0
+}
+
+// Decode 将应答解码为指定的类型，如 *callback.BeforeGroupMessageSendResp
+func (r *Result) Decode(v interface{}) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+// buildBody 构建回调请求体
+func buildBody(defaults map[string]interface{}, payload interface{}) ([]byte, error) {
+	switch p := payload.(type) {
+	case nil:
+		return json.Marshal(defaults)
+	case map[string]interface{}:
+		for k, v := range p {
+			defaults[k] = v
+		}
+		return json.Marshal(defaults)
+	case []byte:
+		return p, nil
+	case json.RawMessage:
+		return p, nil
+	case string:
+		return []byte(p), nil
+	default:
+		return json.Marshal(p)
+	}
+}
@@ -0,0 +1,101 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 回调事件模拟器单元测试
+ */
+
+package callbacktest
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/d60-Lab/tencent-im/callback"
+)
+
+const testAppId = 1400000000
+
+func TestSimulator_SendAllEvents(t *testing.T) {
+	for event := range fixtures {
+		t.Run(event.String(), func(t *testing.T) {
+			var got interface{}
+
+			c := callback.NewCallback(testAppId)
+			c.Register(event, func(ack callback.Ack, data interface{}) {
+				got = data
+				_ = ack.AckSuccess(0)
+			})
+
+			ret, err := NewSimulator(c, Options{AppId: testAppId}).Send(event)
+			if err != nil {
+				t.Fatalf("Send() error = %v", err)
+			}
+			if ret.StatusCode != http.StatusOK || !ret.OK() {
+				t.Errorf("Send() = %d %+v, want OK", ret.StatusCode, ret.Resp)
+			}
+			if got == nil {
+				t.Error("handler was not called")
+			}
+		})
+	}
+}
+
+func TestSimulator_SendOverride(t *testing.T) {
+	var got *callback.AfterGroupMessageSend
+
+	c := callback.NewCallback(testAppId)
+	c.Register(callback.EventAfterGroupMessageSend, func(ack callback.Ack, data interface{}) {
+		got = data.(*callback.AfterGroupMessageSend)
+		_ = ack.AckSuccess(0)
+	})
+
+	s := NewSimulator(c, Options{AppId: testAppId, Token: "token"})
+	if _, err := s.Send(callback.EventAfterGroupMessageSend, map[string]interface{}{"GroupId": "@TGS#override"}); err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	if got == nil || got.GroupId != "@TGS#override" {
+		t.Fatalf("GroupId = %+v, want @TGS#override", got)
+	}
+	if got.FromUserId != "user1" {
+		t.Errorf("FromUserId = %v, want default user1", got.FromUserId)
+	}
+}
+
+func TestResult_Decode(t *testing.T) {
+	c := callback.NewCallback(testAppId)
+	c.Register(callback.EventBeforeInviteJoinGroup, func(ack callback.Ack, data interface{}) {
+		_ = ack.Ack(&callback.BeforeInviteJoinGroupResp{
+			BaseResp:             callback.BaseResp{ActionStatus: ackSuccessStatus},
+			RefusedMemberUserIds: []string{"user5"},
+		})
+	})
+
+	ret, err := NewSimulator(c, Options{AppId: testAppId}).Send(callback.EventBeforeInviteJoinGroup)
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+
+	resp := &callback.BeforeInviteJoinGroupResp{}
+	if err = ret.Decode(resp); err != nil {
+		t.Fatalf("Decode() error = %v", err)
+	}
+	if len(resp.RefusedMemberUserIds) != 1 || resp.RefusedMemberUserIds[0] != "user5" {
+		t.Errorf("RefusedMemberUserIds = %v, want [user5]", resp.RefusedMemberUserIds)
+	}
+}
+
+func TestSimulator_SendUnknownEvent(t *testing.T) {
+	s := NewSimulator(callback.NewCallback(testAppId), Options{AppId: testAppId})
+	if _, err := s.Send(callback.EventUnknown); err != errUnknownEvent {
+		t.Errorf("Send() error = %v, want %v", err, errUnknownEvent)
+	}
+
+	ret, err := s.SendCommand("Foo.CallbackBar", []byte(`{}`))
+	if err != nil {
+		t.Fatalf("SendCommand() error = %v", err)
+	}
+	if !ret.OK() {
+		t.Errorf("SendCommand() = %+v, want OK", ret.Resp)
+	}
+}
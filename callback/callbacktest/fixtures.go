@@ -0,0 +1,206 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 回调事件默认数据
+ */
+
+package callbacktest
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/d60-Lab/tencent-im/callback"
+)
+
+// fixtures 各回调事件的默认数据（字段取值参考腾讯云IM官方文档示例）
+var fixtures = map[callback.Event]string{
+	callback.EventStateChange: `{
+		"CallbackCommand": "State.StateChange",
+		"EventTime": 1700000000000,
+		"Info": {"To_Account": "user1", "Action": "Login", "Reason": "Register"},
+		"KickedDevice": [{"Platform": "Windows"}]
+	}`,
+	callback.EventBeforeFriendAdd: `{
+		"CallbackCommand": "Sns.CallbackPrevFriendAdd",
+		"EventTime": 1700000000000,
+		"Requester_Account": "user1",
+		"From_Account": "user1",
+		"AddType": "Add_Type_Both",
+		"ForceAddFlags": 0,
+		"FriendItem": [{"To_Account": "user2", "Remark": "remark", "GroupName": "friends", "AddSource": "AddSource_Type_Android", "AddWording": "hello"}]
+	}`,
+	callback.EventBeforeFriendResponse: `{
+		"CallbackCommand": "Sns.CallbackPrevFriendResponse",
+		"EventTime": 1700000000000,
+		"Requester_Account": "user2",
+		"From_Account": "user2",
+		"ResponseFriendItem": [{"To_Account": "user1", "Remark": "remark", "TagName": "friends", "ResponseAction": "Response_Action_AgreeAndAdd"}]
+	}`,
+	callback.EventAfterFriendAdd: `{
+		"CallbackCommand": "Sns.CallbackFriendAdd",
+		"ClientCmd": "friend_add",
+		"Admin_Account": "",
+		"ForceFlag": 0,
+		"PairList": [{"From_Account": "user1", "To_Account": "user2", "Initiator_Account": "user1"}]
+	}`,
+	callback.EventAfterFriendDelete: `{
+		"CallbackCommand": "Sns.CallbackFriendDelete",
+		"PairList": [{"From_Account": "user1", "To_Account": "user2"}]
+	}`,
+	callback.EventAfterBlacklistAdd: `{
+		"CallbackCommand": "Sns.CallbackBlackListAdd",
+		"PairList": [{"From_Account": "user1", "To_Account": "user2"}]
+	}`,
+	callback.EventAfterBlacklistDelete: `{
+		"CallbackCommand": "Sns.CallbackBlackListDelete",
+		"PairList": [{"From_Account": "user1", "To_Account": "user2"}]
+	}`,
+	callback.EventBeforePrivateMessageSend: `{
+		"CallbackCommand": "C2C.CallbackBeforeSendMsg",
+		"From_Account": "user1",
+		"To_Account": "user2",
+		"MsgSeq": 48374,
+		"MsgRandom": 2837546,
+		"MsgTime": 1700000000,
+		"MsgKey": "48374_2837546_1700000000",
+		"OnlineOnlyFlag": 0,
+		"MsgBody": [{"MsgType": "TIMTextElem", "MsgContent": {"Text": "hello"}}],
+		"CloudCustomData": ""
+	}`,
+	callback.EventAfterPrivateMessageSend: `{
+		"CallbackCommand": "C2C.CallbackAfterSendMsg",
+		"From_Account": "user1",
+		"To_Account": "user2",
+		"MsgSeq": 48374,
+		"MsgRandom": 2837546,
+		"MsgTime": 1700000000,
+		"MsgKey": "48374_2837546_1700000000",
+		"OnlineOnlyFlag": 0,
+		"MsgBody": [{"MsgType": "TIMTextElem", "MsgContent": {"Text": "hello"}}],
+		"CloudCustomData": "",
+		"SendMsgResult": 0,
+		"ErrorInfo": "send msg succeed",
+		"UnreadMsgNum": 1
+	}`,
+	callback.EventAfterPrivateMessageReport: `{
+		"CallbackCommand": "C2C.CallbackAfterMsgReport",
+		"Report_Account": "user2",
+		"Peer_Account": "user1",
+		"LastReadTime": 1700000000,
+		"UnreadMsgNum": 0
+	}`,
+	callback.EventAfterPrivateMessageRevoke: `{
+		"CallbackCommand": "C2C.CallbackAfterMsgWithDraw",
+		"From_Account": "user1",
+		"To_Account": "user2",
+		"MsgKey": "48374_2837546_1700000000",
+		"UnreadMsgNum": 0
+	}`,
+	callback.EventBeforeGroupCreate: `{
+		"CallbackCommand": "Group.CallbackBeforeCreateGroup",
+		"Operator_Account": "user1",
+		"Owner_Account": "user1",
+		"Type": "Public",
+		"Name": "test group",
+		"CreateGroupNum": 1,
+		"MemberList": [{"Member_Account": "user2"}, {"Member_Account": "user3"}]
+	}`,
+	callback.EventAfterGroupCreate: `{
+		"CallbackCommand": "Group.CallbackAfterCreateGroup",
+		"Operator_Account": "user1",
+		"Owner_Account": "user1",
+		"GroupId": "@TGS#2J4SZEAEL",
+		"Type": "Public",
+		"Name": "test group",
+		"CreateGroupNum": 1,
+		"MemberList": [{"Member_Account": "user2"}, {"Member_Account": "user3"}],
+		"UserDefinedDataList": [{"Key": "userkey", "Value": "uservalue"}]
+	}`,
+	callback.EventBeforeApplyJoinGroup: `{
+		"CallbackCommand": "Group.CallbackBeforeApplyJoinGroup",
+		"GroupId": "@TGS#2J4SZEAEL",
+		"Type": "Public",
+		"Requestor_Account": "user4"
+	}`,
+	callback.EventBeforeInviteJoinGroup: `{
+		"CallbackCommand": "Group.CallbackBeforeInviteJoinGroup",
+		"GroupId": "@TGS#2J4SZEAEL",
+		"Type": "Public",
+		"Operator_Account": "user1",
+		"DestinationMembers": [{"Member_Account": "user4"}, {"Member_Account": "user5"}]
+	}`,
+	callback.EventAfterNewMemberJoinGroup: `{
+		"CallbackCommand": "Group.CallbackAfterNewMemberJoin",
+		"GroupId": "@TGS#2J4SZEAEL",
+		"Type": "Public",
+		"JoinType": "Apply",
+		"Operator_Account": "user4",
+		"NewMemberList": [{"Member_Account": "user4"}]
+	}`,
+	callback.EventAfterMemberExitGroup: `{
+		"CallbackCommand": "Group.CallbackAfterMemberExit",
+		"GroupId": "@TGS#2J4SZEAEL",
+		"Type": "Public",
+		"ExitType": "Kicked",
+		"Operator_Account": "user1",
+		"ExitMemberList": [{"Member_Account": "user4"}]
+	}`,
+	callback.EventBeforeGroupMessageSend: `{
+		"CallbackCommand": "Group.CallbackBeforeSendMsg",
+		"GroupId": "@TGS#2J4SZEAEL",
+		"Type": "Public",
+		"From_Account": "user1",
+		"Operator_Account": "user1",
+		"OnlineOnlyFlag": 0,
+		"Random": 123456,
+		"MsgBody": [{"MsgType": "TIMTextElem", "MsgContent": {"Text": "hello"}}]
+	}`,
+	callback.EventAfterGroupMessageSend: `{
+		"CallbackCommand": "Group.CallbackAfterSendMsg",
+		"GroupId": "@TGS#2J4SZEAEL",
+		"Type": "Public",
+		"From_Account": "user1",
+		"Operator_Account": "user1",
+		"OnlineOnlyFlag": 0,
+		"MsgSeq": 123,
+		"Random": 123456,
+		"MsgTime": 1700000000,
+		"MsgBody": [{"MsgType": "TIMTextElem", "MsgContent": {"Text": "hello"}}]
+	}`,
+	callback.EventAfterGroupFull: `{
+		"CallbackCommand": "Group.CallbackAfterGroupFull",
+		"GroupId": "@TGS#2J4SZEAEL"
+	}`,
+	callback.EventAfterGroupDestroyed: `{
+		"CallbackCommand": "Group.CallbackAfterGroupDestroyed",
+		"GroupId": "@TGS#2J4SZEAEL",
+		"Type": "Public",
+		"Name": "test group",
+		"Owner_Account": "user1",
+		"MemberList": [{"Member_Account": "user1"}, {"Member_Account": "user2"}]
+	}`,
+	callback.EventAfterGroupInfoChanged: `{
+		"CallbackCommand": "Group.CallbackAfterGroupInfoChanged",
+		"GroupId": "@TGS#2J4SZEAEL",
+		"Type": "Public",
+		"Notification": "new notification",
+		"Operator_Account": "user1"
+	}`,
+}
+
+// DefaultPayload 获取事件的默认回调数据，返回值可自由修改
+// 未知事件返回 nil
+func DefaultPayload(event callback.Event) map[string]interface{} {
+	fixture, ok := fixtures[event]
+	if !ok {
+		return nil
+	}
+
+	payload := make(map[string]interface{})
+	decoder := json.NewDecoder(strings.NewReader(fixture))
+	decoder.UseNumber()
+	_ = decoder.Decode(&payload)
+
+	return payload
+}
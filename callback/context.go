@@ -0,0 +1,55 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 回调请求上下文
+ */
+
+package callback
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+type (
+	// RequestInfo 回调请求信息
+	RequestInfo struct {
+		AppId       string        // 应用SDKAppID
+		Command     string        // 回调命令
+		ClientIP    string        // 客户端IP地址
+		OptPlatform string        // 客户端平台
+		ContentType string        // 请求包体格式
+		Request     *http.Request // 原始HTTP请求（异步处理及调用 Replay 回放时为 nil，经 HTTP 回放时为回放请求）
+		Replay      bool          // 是否为回放的事件
+	}
+
+	requestInfoKey struct{}
+)
+
+// newRequestInfo 从回调请求的查询参数中解析请求信息
+func newRequestInfo(query url.Values, r *http.Request) *RequestInfo {
+	return &RequestInfo{
+		AppId:       query.Get(queryAppId),
+		Command:     query.Get(queryCommand),
+		ClientIP:    query.Get(queryClientId),
+		OptPlatform: query.Get(queryOptPlatform),
+		ContentType: query.Get(queryContentType),
+		Request:     r,
+	}
+}
+
+// WithRequestInfo 将回调请求信息写入上下文
+func WithRequestInfo(ctx context.Context, info *RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+// RequestInfoFromContext 从上下文中获取回调请求信息
+func RequestInfoFromContext(ctx context.Context) (*RequestInfo, bool) {
+	if ctx == nil {
+		return nil, false
+	}
+
+	info, ok := ctx.Value(requestInfoKey{}).(*RequestInfo)
+	return info, ok
+}
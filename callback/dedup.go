@@ -0,0 +1,289 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 回调事件去重实现
+ */
+
+package callback
+
+import (
+	"container/list"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+)
+
+const (
+	defaultDedupTTL      = 10 * time.Minute
+	defaultDedupCapacity = 100000
+)
+
+const (
+	DedupModeSkip DedupMode = iota // 跳过重复事件并应答成功（发生之前回调不去重）
+	DedupModeFlag                  // 标记重复事件后继续处理，可通过 IsDuplicate 判断
+)
+
+type (
+	// DedupMode 重复事件处理方式
+	DedupMode int
+
+	// DedupStore 去重存储
+	DedupStore interface {
+		// SetIfAbsent 写入幂等键，幂等键已存在且未过期时返回 false
+		SetIfAbsent(key string, ttl time.Duration) (bool, error)
+		// Delete 删除幂等键（事件处理失败时删除，以便重试的投递能够被重新处理）
+		Delete(key string) error
+	}
+
+	// DedupOptions 去重配置
+	DedupOptions struct {
+		Store   DedupStore                                         // （选填）去重存储，默认使用容量为10万的内存存储
+		TTL     time.Duration                                      // （选填）幂等键有效期，默认为10分钟
+		Mode    DedupMode                                          // （选填）重复事件处理方式，默认跳过
+		KeyFunc func(event Event, data interface{}) (string, bool) // （选填）幂等键生成函数，默认为 EventKey
+	}
+
+	// memoryDedupStore 基于内存的有界去重存储
+	memoryDedupStore struct {
+		mu       sync.Mutex
+		capacity int
+		items    map[string]*list.Element
+		order    *list.List
+	}
+
+	memoryDedupEntry struct {
+		key      string
+		expireAt time.Time
+	}
+
+	// dedupAck 标记重复事件的应答器
+	dedupAck struct {
+		ack Ack
+	}
+
+	// ackUnwrapper 可获取被包装应答器的应答器（自定义中间件包装应答器时应实现 Unwrap 方法）
+	ackUnwrapper interface {
+		Unwrap() Ack
+	}
+)
+
+// NewMemoryDedupStore 新建基于内存的去重存储
+// 超出容量时淘汰最早写入的幂等键
+func NewMemoryDedupStore(capacity int) DedupStore {
+	if capacity <= 0 {
+		capacity = defaultDedupCapacity
+	}
+
+	return &memoryDedupStore{
+		capacity: capacity,
+		items:    make(map[string]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// SetIfAbsent 写入幂等键
+func (s *memoryDedupStore) SetIfAbsent(key string, ttl time.Duration) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+
+	if e, ok := s.items[key]; ok {
+		if e.Value.(*memoryDedupEntry).expireAt.After(now) {
+			return false, nil
+		}
+		s.remove(e)
+	}
+
+	for s.order.Len() >= s.capacity {
+		s.remove(s.order.Front())
+	}
+
+	s.items[key] = s.order.PushBack(&memoryDedupEntry{key: key, expireAt: now.Add(ttl)})
+
+	return true, nil
+}
+
+// Delete 删除幂等键
+func (s *memoryDedupStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if e, ok := s.items[key]; ok {
+		s.remove(e)
+	}
+
+	return nil
+}
+
+// remove 移除幂等键
+func (s *memoryDedupStore) remove(e *list.Element) {
+	s.order.Remove(e)
+	delete(s.items, e.Value.(*memoryDedupEntry).key)
+}
+
+// Dedup 去重中间件
+// 腾讯云IM后台在回调超时时会重试投递，该中间件按 EventKey 生成的幂等键识别重复投递的事件
+// 事件处理失败（应答失败或发生 panic）时会删除幂等键，以便后续的重试投递能够被重新处理
+// 发生之前回调的成功应答表示放行，跳过模式下直接应答成功会让重试投递绕过首次处理的拦截或修改结果，因此跳过模式不对发生之前回调去重
+// 回放的事件（RequestInfo.Replay）不经过去重
+func Dedup(opt ...DedupOptions) Middleware {
+	o := DedupOptions{}
+	if len(opt) > 0 {
+		o = opt[0]
+	}
+
+	if o.Store == nil {
+		o.Store = NewMemoryDedupStore(defaultDedupCapacity)
+	}
+	if o.TTL <= 0 {
+		o.TTL = defaultDedupTTL
+	}
+	if o.KeyFunc == nil {
+		o.KeyFunc = EventKey
+	}
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, event Event, ack Ack, data interface{}) {
+			if o.Mode == DedupModeSkip && event.IsBefore() {
+				next(ctx, event, ack, data)
+				return
+			}
+
+			if info, ok := RequestInfoFromContext(ctx); ok && info.Replay {
+				next(ctx, event, ack, data)
+				return
+			}
+
+			key, ok := o.KeyFunc(event, data)
+			if !ok {
+				next(ctx, event, ack, data)
+				return
+			}
+
+			added, err := o.Store.SetIfAbsent(key, o.TTL)
+			if err != nil {
+				next(ctx, event, ack, data)
+				return
+			}
+
+			if !added {
+				if o.Mode == DedupModeFlag {
+					next(ctx, event, &dedupAck{ack: ack}, data)
+				} else {
+					_ = ack.AckSuccess(ackSuccessCode)
+				}
+				return
+			}
+
+			ra := &recordAck{ack: ack}
+
+			defer func() {
+				r := recover()
+				if _, failed := ra.result(); failed || r != nil {
+					_ = o.Store.Delete(key)
+				}
+				if r != nil {
+					panic(r)
+				}
+			}()
+
+			next(ctx, event, ra, data)
+		}
+	}
+}
+
+// IsDuplicate 判断当前回调是否为重复投递的事件（仅在 DedupModeFlag 模式下有效）
+func IsDuplicate(ack Ack) bool {
+	for ack != nil {
+		if _, ok := ack.(*dedupAck); ok {
+			return true
+		}
+
+		u, ok := ack.(ackUnwrapper)
+		if !ok {
+			return false
+		}
+		ack = u.Unwrap()
+	}
+
+	return false
+}
+
+// EventKey 生成事件的幂等键
+// 消息类事件优先使用 MsgKey 或 GroupId+MsgSeq，其他事件使用事件时间及操作者，无法识别的事件使用事件内容摘要
+func EventKey(event Event, data interface{}) (string, bool) {
+	var key string
+
+	switch d := data.(type) {
+	case *StateChange:
+		key = fmt.Sprintf("%s:%d:%s", d.Info.UserId, d.EventTime, d.Info.Action)
+	case *BeforeFriendAdd:
+		key = fmt.Sprintf("%s:%s:%d", d.RequesterUserId, d.FromUserId, d.EventTime)
+	case *BeforeFriendResponse:
+		key = fmt.Sprintf("%s:%s:%d", d.RequesterUserId, d.FromUserId, d.EventTime)
+	case *BeforePrivateMessageSend:
+		key = privateMessageKey(d.MsgKey, d.FromUserId, d.ToUserId, d.MsgSeq, d.MsgRandom, d.MsgTime)
+	case *AfterPrivateMessageSend:
+		key = privateMessageKey(d.MsgKey, d.FromUserId, d.ToUserId, d.MsgSeq, d.MsgRandom, d.MsgTime)
+	case *AfterPrivateMessageReport:
+		key = fmt.Sprintf("%s:%s:%d", d.ReportUserId, d.PeerUserId, d.LastReadTime)
+	case *AfterPrivateMessageRevoke:
+		key = d.MsgKey
+	case *BeforeGroupMessageSend:
+		key = fmt.Sprintf("%s:%s:%d", d.GroupId, d.FromUserId, d.MsgRandom)
+	case *AfterGroupMessageSend:
+		key = fmt.Sprintf("%s:%d", d.GroupId, d.MsgSeq)
+	case *UnknownEvent:
+		return d.CallbackCommand + ":" + digest(d.Body), len(d.Body) > 0
+	}
+
+	if key == "" {
+		b, err := json.Marshal(data)
+		if err != nil {
+			return "", false
+		}
+		key = digest(b)
+	}
+
+	return event.String() + ":" + key, true
+}
+
+// privateMessageKey 生成单聊消息的幂等键
+func privateMessageKey(msgKey, from, to string, seq, random int, msgTime int64) string {
+	if msgKey != "" {
+		return msgKey
+	}
+
+	return fmt.Sprintf("%s:%s:%d:%d:%d", from, to, seq, random, msgTime)
+}
+
+// digest 计算内容摘要
+func digest(b []byte) string {
+	sum := sha1.Sum(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// Ack 应答
+func (a *dedupAck) Ack(resp interface{}) error {
+	return a.ack.Ack(resp)
+}
+
+// AckFailure 失败应答
+func (a *dedupAck) AckFailure(message ...string) error {
+	return a.ack.AckFailure(message...)
+}
+
+// AckSuccess 成功应答
+func (a *dedupAck) AckSuccess(code int, message ...string) error {
+	return a.ack.AckSuccess(code, message...)
+}
+
+// Unwrap 获取被包装的应答器
+func (a *dedupAck) Unwrap() Ack {
+	return a.ack
+}
@@ -0,0 +1,195 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 回调事件去重单元测试
+ */
+
+package callback
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestMemoryDedupStore(t *testing.T) {
+	s := NewMemoryDedupStore(2)
+
+	if ok, _ := s.SetIfAbsent("a", time.Minute); !ok {
+		t.Error("SetIfAbsent(a) = false, want true")
+	}
+	if ok, _ := s.SetIfAbsent("a", time.Minute); ok {
+		t.Error("SetIfAbsent(a) again = true, want false")
+	}
+
+	_, _ = s.SetIfAbsent("b", time.Minute)
+	_, _ = s.SetIfAbsent("c", time.Minute)
+	if ok, _ := s.SetIfAbsent("a", time.Minute); !ok {
+		t.Error("SetIfAbsent(a) after eviction = false, want true")
+	}
+
+	_ = s.Delete("c")
+	if ok, _ := s.SetIfAbsent("c", time.Minute); !ok {
+		t.Error("SetIfAbsent(c) after delete = false, want true")
+	}
+
+	if ok, _ := s.SetIfAbsent("d", -time.Second); !ok {
+		t.Error("SetIfAbsent(d) = false, want true")
+	}
+	if ok, _ := s.SetIfAbsent("d", time.Minute); !ok {
+		t.Error("SetIfAbsent(d) after expiry = false, want true")
+	}
+}
+
+func TestEventKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		event Event
+		data  interface{}
+		want  string
+	}{
+		{
+			name:  "private message with msg key",
+			event: EventAfterPrivateMessageSend,
+			data:  &AfterPrivateMessageSend{MsgKey: "key1", FromUserId: "a", ToUserId: "b"},
+			want:  commandAfterPrivateMessageSend + ":key1",
+		},
+		{
+			name:  "group message",
+			event: EventAfterGroupMessageSend,
+			data:  &AfterGroupMessageSend{GroupId: "@TGS#1", MsgSeq: 42},
+			want:  commandAfterGroupMessageSend + ":@TGS#1:42",
+		},
+		{
+			name:  "state change",
+			event: EventStateChange,
+			data: func() *StateChange {
+				d := &StateChange{EventTime: 1000}
+				d.Info.UserId, d.Info.Action = "u1", "Login"
+				return d
+			}(),
+			want: commandStateChange + ":u1:1000:Login",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got, ok := EventKey(tt.event, tt.data); !ok || got != tt.want {
+				t.Errorf("EventKey() = %v, %v, want %v", got, ok, tt.want)
+			}
+		})
+	}
+
+	a, _ := EventKey(EventAfterGroupFull, &AfterGroupFull{GroupId: "1"})
+	b, _ := EventKey(EventAfterGroupFull, &AfterGroupFull{GroupId: "2"})
+	if a == b {
+		t.Errorf("EventKey() digest fallback collides: %v", a)
+	}
+}
+
+func TestDedup(t *testing.T) {
+	const body = `{"GroupId":"@TGS#1","MsgSeq":7}`
+
+	t.Run("skip duplicates", func(t *testing.T) {
+		calls := 0
+
+		c := NewCallback(testAppId)
+		c.Use(Dedup())
+		c.Register(EventAfterGroupMessageSend, func(ack Ack, data interface{}) {
+			calls++
+			_ = ack.AckSuccess(ackSuccessCode)
+		})
+
+		for i := 0; i < 3; i++ {
+			w := httptest.NewRecorder()
+			c.Listen(w, newTestRequest(commandAfterGroupMessageSend, body))
+			if got := decodeTestResp(t, w); got.ActionStatus != ackSuccessStatus {
+				t.Errorf("ActionStatus = %v, want %v", got.ActionStatus, ackSuccessStatus)
+			}
+		}
+
+		if calls != 1 {
+			t.Errorf("handler calls = %d, want 1", calls)
+		}
+	})
+
+	t.Run("flag duplicates", func(t *testing.T) {
+		var flags []bool
+
+		c := NewCallback(testAppId)
+		c.Use(Dedup(DedupOptions{Mode: DedupModeFlag}), Logging(&testLogger{}))
+		c.Register(EventAfterGroupMessageSend, func(ack Ack, data interface{}) {
+			flags = append(flags, IsDuplicate(ack))
+			_ = ack.AckSuccess(ackSuccessCode)
+		})
+
+		c.Listen(httptest.NewRecorder(), newTestRequest(commandAfterGroupMessageSend, body))
+		c.Listen(httptest.NewRecorder(), newTestRequest(commandAfterGroupMessageSend, body))
+
+		if len(flags) != 2 || flags[0] || !flags[1] {
+			t.Errorf("duplicate flags = %v, want [false true]", flags)
+		}
+	})
+
+	t.Run("reprocess after failure", func(t *testing.T) {
+		calls := 0
+
+		c := NewCallback(testAppId)
+		c.Use(Dedup())
+		c.Register(EventAfterGroupMessageSend, func(ack Ack, data interface{}) {
+			calls++
+			_ = ack.AckFailure("temporary failure")
+		})
+
+		c.Listen(httptest.NewRecorder(), newTestRequest(commandAfterGroupMessageSend, body))
+		c.Listen(httptest.NewRecorder(), newTestRequest(commandAfterGroupMessageSend, body))
+
+		if calls != 2 {
+			t.Errorf("handler calls = %d, want 2", calls)
+		}
+	})
+	t.Run("before events are not skipped", func(t *testing.T) {
+		calls := 0
+
+		c := NewCallback(testAppId)
+		c.Use(Dedup())
+		c.Register(EventBeforeGroupMessageSend, func(ack Ack, data interface{}) {
+			calls++
+			_ = ack.AckSuccess(ackSuccessCode + 1)
+		})
+
+		const before = `{"GroupId":"@TGS#1","From_Account":"u1","Random":7}`
+		for i := 0; i < 2; i++ {
+			w := httptest.NewRecorder()
+			c.Listen(w, newTestRequest(commandBeforeGroupMessageSend, before))
+			if got := decodeTestResp(t, w); got.ErrorCode != ackSuccessCode+1 {
+				t.Errorf("ErrorCode = %v, want the handler's answer for every delivery", got.ErrorCode)
+			}
+		}
+
+		if calls != 2 {
+			t.Errorf("handler calls = %d, want 2", calls)
+		}
+	})
+
+	t.Run("replay bypasses dedup", func(t *testing.T) {
+		calls := 0
+
+		c := NewCallback(testAppId)
+		c.Use(Dedup())
+		c.Register(EventAfterGroupMessageSend, func(ack Ack, data interface{}) {
+			calls++
+			_ = ack.AckSuccess(ackSuccessCode)
+		})
+
+		c.Listen(httptest.NewRecorder(), newTestRequest(commandAfterGroupMessageSend, body))
+		if err := c.(*callback).handleDetached(context.Background(), commandAfterGroupMessageSend, "", []byte(body), true); err != nil {
+			t.Fatalf("handleDetached() error = %v", err)
+		}
+
+		if calls != 2 {
+			t.Errorf("handler calls = %d, want 2", calls)
+		}
+	})
+}
@@ -0,0 +1,402 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 回调事件转发实现（CloudEvents 1.0）
+ */
+
+package callback
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	cloudEventsSpecVersion     = "1.0"
+	cloudEventsContentType     = "application/json"
+	defaultCloudEventsTypeBase = "com.tencent.im."
+	defaultCloudEventsSource   = "/tencent-im/"
+
+	defaultForwardQueueSize = 1024
+	defaultForwardWorkers   = 4
+	defaultForwardTimeout   = 10 * time.Second
+)
+
+var (
+	// ErrForwardQueueFull 转发队列已满，事件被丢弃
+	ErrForwardQueueFull = errors.New("callback forward queue is full")
+	// ErrForwarderClosed 转发器已关闭或关闭时未能在期限内投递，事件被丢弃
+	ErrForwarderClosed = errors.New("callback forwarder is closed")
+)
+
+type (
+	// CloudEvent CloudEvents 1.0 结构化事件
+	CloudEvent struct {
+		SpecVersion     string          `json:"specversion"`               // 规范版本，固定为1.0
+		Id              string          `json:"id"`                        // 事件ID，同一事件的重复投递ID相同
+		Source          string          `json:"source"`                    // 事件来源
+		Type            string          `json:"type"`                      // 事件类型，由回调命令生成，如 com.tencent.im.Group.CallbackAfterSendMsg
+		Subject         string          `json:"subject,omitempty"`         // 事件主题，群组事件为群ID，其他事件为用户ID
+		Time            time.Time       `json:"time"`                      // 事件发生时间
+		DataContentType string          `json:"datacontenttype,omitempty"` // 事件数据格式
+		Data            json.RawMessage `json:"data,omitempty"`            // 事件数据
+	}
+
+	// Sink 事件投递目标
+	Sink interface {
+		// Publish 投递事件
+		Publish(ctx context.Context, e *CloudEvent) error
+	}
+
+	// ForwardSink 事件投递目标及过滤规则
+	ForwardSink struct {
+		Sink   Sink                   // （必填）事件投递目标
+		Events []Event                // （选填）投递的事件，默认投递全部事件
+		Filter func(*CloudEvent) bool // （选填）自定义过滤函数，返回 false 时不投递
+	}
+
+	// ForwardOptions 事件转发配置
+	ForwardOptions struct {
+		Sinks      []ForwardSink                                       // （必填）事件投递目标
+		Source     string                                              // （选填）事件来源，默认为 /tencent-im/{SdkAppid}
+		TypePrefix string                                              // （选填）事件类型前缀，默认为 com.tencent.im.
+		OnError    func(ctx context.Context, e *CloudEvent, err error) // （选填）投递失败或事件被丢弃时的回调
+		QueueSize  int                                                 // （选填）待投递事件的队列长度，队列已满时丢弃事件，默认为1024
+		Workers    int                                                 // （选填）投递协程数，默认为4
+		Timeout    time.Duration                                       // （选填）单个目标单次投递的超时时间，默认为10秒
+	}
+
+	// forwardRoute 事件投递路由
+	forwardRoute struct {
+		sink   Sink
+		events map[Event]bool
+		filter func(*CloudEvent) bool
+	}
+
+	// forwardJob 待投递事件
+	forwardJob struct {
+		ctx   context.Context
+		event *CloudEvent
+		sinks []Sink
+	}
+
+	// Forwarder 事件转发器
+	Forwarder struct {
+		opt     ForwardOptions
+		routes  []*forwardRoute
+		jobs    chan *forwardJob
+		mu      sync.RWMutex
+		closed  bool
+		aborted atomic.Bool
+		stop    context.Context
+		cancel  context.CancelFunc
+		wg      sync.WaitGroup
+	}
+)
+
+// NewForwarder 新建事件转发器并启动投递协程
+// 事件处理完成后，将事件转换为 CloudEvents 1.0 结构放入有界队列，由后台协程投递至匹配的目标
+// 投递不阻塞应答，投递失败或队列已满（ErrForwardQueueFull）时事件被丢弃并通过 OnError 通知
+// 不再使用时应调用 Close 停止投递协程并投递队列中剩余的事件
+func NewForwarder(opt ForwardOptions) *Forwarder {
+	if opt.TypePrefix == "" {
+		opt.TypePrefix = defaultCloudEventsTypeBase
+	}
+	if opt.QueueSize <= 0 {
+		opt.QueueSize = defaultForwardQueueSize
+	}
+	if opt.Workers <= 0 {
+		opt.Workers = defaultForwardWorkers
+	}
+	if opt.Timeout <= 0 {
+		opt.Timeout = defaultForwardTimeout
+	}
+
+	f := &Forwarder{
+		opt:    opt,
+		routes: make([]*forwardRoute, 0, len(opt.Sinks)),
+		jobs:   make(chan *forwardJob, opt.QueueSize),
+	}
+	f.stop, f.cancel = context.WithCancel(context.Background())
+
+	for _, s := range opt.Sinks {
+		if s.Sink == nil {
+			continue
+		}
+
+		r := &forwardRoute{sink: s.Sink, filter: s.Filter}
+		if len(s.Events) > 0 {
+			r.events = make(map[Event]bool, len(s.Events))
+			for _, event := range s.Events {
+				r.events[event] = true
+			}
+		}
+
+		f.routes = append(f.routes, r)
+	}
+
+	f.wg.Add(opt.Workers)
+	for i := 0; i < opt.Workers; i++ {
+		go f.forward()
+	}
+
+	return f
+}
+
+// Middleware 获取事件转发中间件
+// 转发器关闭后到达的事件被丢弃，并以 ErrForwarderClosed 通过 OnError 通知
+func (f *Forwarder) Middleware() Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, event Event, ack Ack, data interface{}) {
+			next(ctx, event, ack, data)
+
+			var job *forwardJob
+			for _, r := range f.routes {
+				if r.events != nil && !r.events[event] {
+					continue
+				}
+
+				if job == nil {
+					e, err := NewCloudEvent(ctx, event, data, f.opt.Source, f.opt.TypePrefix)
+					if err != nil {
+						f.notify(ctx, nil, err)
+						return
+					}
+
+					// 请求结束后上下文即被取消，投递时仅保留其中的值
+					job = &forwardJob{ctx: context.WithoutCancel(ctx), event: e}
+				}
+
+				if r.filter != nil && !r.filter(job.event) {
+					continue
+				}
+
+				job.sinks = append(job.sinks, r.sink)
+			}
+
+			if job == nil || len(job.sinks) == 0 {
+				return
+			}
+
+			f.push(job)
+		}
+	}
+}
+
+// Close 关闭转发器，不再接收新事件，等待队列中剩余的事件投递完成
+// ctx 结束时停止等待，进行中的投递被取消，未投递的事件以 ErrForwarderClosed 通过 OnError 通知，并返回 ctx 的错误
+func (f *Forwarder) Close(ctx context.Context) error {
+	f.mu.Lock()
+	if !f.closed {
+		f.closed = true
+		close(f.jobs)
+	}
+	f.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		f.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		f.cancel()
+		return nil
+	case <-ctx.Done():
+		f.aborted.Store(true)
+		f.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// push 将事件放入投递队列
+func (f *Forwarder) push(job *forwardJob) {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	if f.closed {
+		f.notify(job.ctx, job.event, ErrForwarderClosed)
+		return
+	}
+
+	select {
+	case f.jobs <- job:
+	default:
+		f.notify(job.ctx, job.event, ErrForwardQueueFull)
+	}
+}
+
+// forward 投递队列中的事件，每个目标的投递均受 Timeout 限制
+func (f *Forwarder) forward() {
+	defer f.wg.Done()
+
+	for job := range f.jobs {
+		for _, sink := range job.sinks {
+			if f.aborted.Load() {
+				f.notify(job.ctx, job.event, ErrForwarderClosed)
+				continue
+			}
+
+			ctx, cancel := context.WithTimeout(job.ctx, f.opt.Timeout)
+			stop := context.AfterFunc(f.stop, cancel)
+			err := sink.Publish(ctx, job.event)
+			stop()
+			cancel()
+
+			if err != nil {
+				f.notify(job.ctx, job.event, err)
+			}
+		}
+	}
+}
+
+// notify 通知投递失败或被丢弃的事件
+func (f *Forwarder) notify(ctx context.Context, e *CloudEvent, err error) {
+	if f.opt.OnError != nil {
+		f.opt.OnError(ctx, e, err)
+	}
+}
+
+// NewCloudEvent 将回调事件转换为 CloudEvents 1.0 结构
+// source 为空时使用 /tencent-im/{SdkAppid}，typePrefix 为空时使用 com.tencent.im.
+func NewCloudEvent(ctx context.Context, event Event, data interface{}, source, typePrefix string) (*CloudEvent, error) {
+	var (
+		command string
+		body    json.RawMessage
+		err     error
+	)
+
+	if d, ok := data.(*UnknownEvent); ok {
+		command, body = d.CallbackCommand, d.Body
+	} else {
+		command = event.String()
+		if body, err = json.Marshal(data); err != nil {
+			return nil, err
+		}
+	}
+
+	if source == "" {
+		source = defaultCloudEventsSource
+		if info, ok := RequestInfoFromContext(ctx); ok {
+			source += info.AppId
+		}
+	}
+
+	if typePrefix == "" {
+		typePrefix = defaultCloudEventsTypeBase
+	}
+
+	id, ok := EventKey(event, data)
+	if !ok {
+		id = command + ":" + digest(body)
+	}
+
+	return &CloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		Id:              id,
+		Source:          source,
+		Type:            typePrefix + command,
+		Subject:         EventSubject(data),
+		Time:            eventTime(data),
+		DataContentType: cloudEventsContentType,
+		Data:            body,
+	}, nil
+}
+
+// EventSubject 获取事件主题，群组事件为群ID，其他事件为触发事件的用户ID
+func EventSubject(data interface{}) string {
+	switch d := data.(type) {
+	case *StateChange:
+		return d.Info.UserId
+	case *BeforeFriendAdd:
+		return d.FromUserId
+	case *BeforeFriendResponse:
+		return d.FromUserId
+	case *AfterFriendAdd:
+		if len(d.PairList) > 0 {
+			return d.PairList[0].FromUserId
+		}
+	case *AfterFriendDelete:
+		if len(d.PairList) > 0 {
+			return d.PairList[0].FromUserId
+		}
+	case *AfterBlacklistAdd:
+		if len(d.PairList) > 0 {
+			return d.PairList[0].FromUserId
+		}
+	case *AfterBlacklistDelete:
+		if len(d.PairList) > 0 {
+			return d.PairList[0].FromUserId
+		}
+	case *BeforePrivateMessageSend:
+		return d.FromUserId
+	case *AfterPrivateMessageSend:
+		return d.FromUserId
+	case *AfterPrivateMessageReport:
+		return d.ReportUserId
+	case *AfterPrivateMessageRevoke:
+		return d.FromUserId
+	case *BeforeGroupCreate:
+		return d.OwnerUserId
+	case *AfterGroupCreate:
+		return d.GroupId
+	case *BeforeApplyJoinGroup:
+		return d.GroupId
+	case *BeforeInviteJoinGroup:
+		return d.GroupId
+	case *AfterNewMemberJoinGroup:
+		return d.GroupId
+	case *AfterMemberExitGroup:
+		return d.GroupId
+	case *BeforeGroupMessageSend:
+		return d.GroupId
+	case *AfterGroupMessageSend:
+		return d.GroupId
+	case *AfterGroupFull:
+		return d.GroupId
+	case *AfterGroupDestroyed:
+		return d.GroupId
+	case *AfterGroupInfoChanged:
+		return d.GroupId
+	}
+
+	return ""
+}
+
+// eventTime 获取事件发生时间，回调数据中无时间信息时使用当前时间
+func eventTime(data interface{}) time.Time {
+	switch d := data.(type) {
+	case *StateChange:
+		if d.EventTime > 0 {
+			return time.UnixMilli(d.EventTime).UTC()
+		}
+	case *BeforeFriendAdd:
+		if d.EventTime > 0 {
+			return time.UnixMilli(d.EventTime).UTC()
+		}
+	case *BeforeFriendResponse:
+		if d.EventTime > 0 {
+			return time.UnixMilli(d.EventTime).UTC()
+		}
+	case *BeforePrivateMessageSend:
+		if d.MsgTime > 0 {
+			return time.Unix(d.MsgTime, 0).UTC()
+		}
+	case *AfterPrivateMessageSend:
+		if d.MsgTime > 0 {
+			return time.Unix(d.MsgTime, 0).UTC()
+		}
+	case *AfterGroupMessageSend:
+		if d.MsgTime > 0 {
+			return time.Unix(d.MsgTime, 0).UTC()
+		}
+	}
+
+	return time.Now().UTC()
+}
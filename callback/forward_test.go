@@ -0,0 +1,310 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 回调事件转发单元测试
+ */
+
+package callback
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestForward(t *testing.T) {
+	all := make(chan *CloudEvent, 10)
+	groups := make(chan *CloudEvent, 10)
+
+	c := NewCallback(testAppId)
+	f := NewForwarder(ForwardOptions{
+		Sinks: []ForwardSink{
+			{Sink: NewChannelSink(all)},
+			{Sink: NewChannelSink(groups), Events: []Event{EventAfterGroupMessageSend}},
+		},
+	})
+	defer f.Close(context.Background())
+	c.Use(f.Middleware())
+
+	c.Listen(httptest.NewRecorder(), newTestRequest(commandAfterGroupMessageSend, `{"GroupId":"@TGS#1","MsgSeq":7,"MsgTime":1700000000}`))
+	c.Listen(httptest.NewRecorder(), newTestRequest(commandStateChange, `{"EventTime":1700000000000,"Info":{"To_Account":"user1","Action":"Login"}}`))
+
+	waitPublished(t, all, 2)
+	waitPublished(t, groups, 1)
+
+	e := <-groups
+	if e.SpecVersion != "1.0" || e.Type != "com.tencent.im."+commandAfterGroupMessageSend {
+		t.Errorf("envelope = %+v", e)
+	}
+	if e.Subject != "@TGS#1" || e.Source != "/tencent-im/1400000000" {
+		t.Errorf("Subject = %v, Source = %v", e.Subject, e.Source)
+	}
+	if !e.Time.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("Time = %v, want %v", e.Time, time.Unix(1700000000, 0))
+	}
+	if e.Id != commandAfterGroupMessageSend+":@TGS#1:7" {
+		t.Errorf("Id = %v", e.Id)
+	}
+
+	subjects := map[string]bool{(<-all).Subject: true, (<-all).Subject: true}
+	if !subjects["user1"] || !subjects["@TGS#1"] {
+		t.Errorf("subjects = %v, want user1 and @TGS#1", subjects)
+	}
+}
+
+func TestForward_Filter(t *testing.T) {
+	ch := make(chan *CloudEvent, 10)
+
+	c := NewCallback(testAppId)
+	f := NewForwarder(ForwardOptions{
+		Sinks: []ForwardSink{{
+			Sink:   NewChannelSink(ch),
+			Filter: func(e *CloudEvent) bool { return e.Subject == "@TGS#2" },
+		}},
+	})
+	defer f.Close(context.Background())
+	c.Use(f.Middleware())
+
+	c.Listen(httptest.NewRecorder(), newTestRequest(commandAfterGroupMessageSend, `{"GroupId":"@TGS#1"}`))
+	c.Listen(httptest.NewRecorder(), newTestRequest(commandAfterGroupMessageSend, `{"GroupId":"@TGS#2"}`))
+
+	waitPublished(t, ch, 1)
+	time.Sleep(20 * time.Millisecond)
+	if len(ch) != 1 {
+		t.Fatalf("published = %d, want 1", len(ch))
+	}
+}
+
+func TestForward_SlowSink(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	errs := make(chan error, 10)
+	c := NewCallback(testAppId)
+	f := NewForwarder(ForwardOptions{
+		Sinks: []ForwardSink{{Sink: sinkFunc(func(ctx context.Context, e *CloudEvent) error {
+			select {
+			case <-release:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		})}},
+		QueueSize: 1,
+		Workers:   1,
+		Timeout:   200 * time.Millisecond,
+		OnError: func(ctx context.Context, e *CloudEvent, err error) {
+			errs <- err
+		},
+	})
+	defer f.Close(context.Background())
+	c.Use(f.Middleware())
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		w := httptest.NewRecorder()
+		c.Listen(w, newTestRequest(commandAfterGroupMessageSend, `{"GroupId":"@TGS#1"}`))
+		if resp := decodeTestResp(t, w); resp.ErrorCode != 0 {
+			t.Fatalf("resp = %+v", resp)
+		}
+	}
+	if elapsed := time.Since(start); elapsed > 100*time.Millisecond {
+		t.Errorf("ack took %v, want it not to wait for the sink", elapsed)
+	}
+
+	var full, timeout int
+	for full+timeout < 3 {
+		select {
+		case err := <-errs:
+			switch err {
+			case ErrForwardQueueFull:
+				full++
+			case context.DeadlineExceeded:
+				timeout++
+			default:
+				t.Fatalf("unexpected error: %v", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatalf("errors = %d full, %d timeout, want 3 in total", full, timeout)
+		}
+	}
+	if full == 0 || timeout == 0 {
+		t.Errorf("errors = %d full, %d timeout, want both", full, timeout)
+	}
+}
+
+func TestForwarder_Close(t *testing.T) {
+	t.Run("drain", func(t *testing.T) {
+		var published atomic.Int32
+		errs := make(chan error, 10)
+
+		c := NewCallback(testAppId)
+		f := NewForwarder(ForwardOptions{
+			Sinks: []ForwardSink{{Sink: sinkFunc(func(ctx context.Context, e *CloudEvent) error {
+				time.Sleep(10 * time.Millisecond)
+				published.Add(1)
+				return nil
+			})}},
+			Workers: 1,
+			OnError: func(ctx context.Context, e *CloudEvent, err error) {
+				errs <- err
+			},
+		})
+		c.Use(f.Middleware())
+
+		for i := 0; i < 3; i++ {
+			c.Listen(httptest.NewRecorder(), newTestRequest(commandAfterGroupMessageSend, `{"GroupId":"@TGS#1"}`))
+		}
+
+		if err := f.Close(context.Background()); err != nil {
+			t.Fatalf("Close() error = %v", err)
+		}
+		if n := published.Load(); n != 3 {
+			t.Errorf("published = %d, want 3", n)
+		}
+
+		c.Listen(httptest.NewRecorder(), newTestRequest(commandAfterGroupMessageSend, `{"GroupId":"@TGS#1"}`))
+		if err := <-errs; err != ErrForwarderClosed {
+			t.Errorf("OnError err = %v, want %v", err, ErrForwarderClosed)
+		}
+	})
+
+	t.Run("deadline", func(t *testing.T) {
+		errs := make(chan error, 10)
+
+		c := NewCallback(testAppId)
+		f := NewForwarder(ForwardOptions{
+			Sinks: []ForwardSink{{Sink: sinkFunc(func(ctx context.Context, e *CloudEvent) error {
+				<-ctx.Done()
+				return ctx.Err()
+			})}},
+			Workers: 1,
+			OnError: func(ctx context.Context, e *CloudEvent, err error) {
+				errs <- err
+			},
+		})
+		c.Use(f.Middleware())
+
+		for i := 0; i < 3; i++ {
+			c.Listen(httptest.NewRecorder(), newTestRequest(commandAfterGroupMessageSend, `{"GroupId":"@TGS#1"}`))
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		if err := f.Close(ctx); err != context.DeadlineExceeded {
+			t.Fatalf("Close() error = %v, want %v", err, context.DeadlineExceeded)
+		}
+
+		if len(errs) != 3 {
+			t.Fatalf("OnError calls = %d, want 3", len(errs))
+		}
+		if err := <-errs; err != context.Canceled {
+			t.Errorf("in-flight err = %v, want %v", err, context.Canceled)
+		}
+		for i := 0; i < 2; i++ {
+			if err := <-errs; err != ErrForwarderClosed {
+				t.Errorf("queued err = %v, want %v", err, ErrForwarderClosed)
+			}
+		}
+	})
+}
+
+func TestChannelSink_Full(t *testing.T) {
+	sink := NewChannelSink(make(chan *CloudEvent, 1))
+
+	if err := sink.Publish(context.Background(), &CloudEvent{Id: "1"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if err := sink.Publish(context.Background(), &CloudEvent{Id: "2"}); err != ErrChannelSinkFull {
+		t.Fatalf("Publish() error = %v, want %v", err, ErrChannelSinkFull)
+	}
+}
+
+func TestHTTPSink(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&calls, 1) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.Header.Get("Content-Type") != cloudEventsStructuredContentType {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer srv.Close()
+
+	sink := NewHTTPSink(HTTPSinkOptions{Url: srv.URL, Backoff: time.Millisecond})
+	if err := sink.Publish(context.Background(), &CloudEvent{Id: "1"}); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("calls = %d, want 2", calls)
+	}
+}
+
+func TestFileSink(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events", "events.jsonl")
+
+	sink, err := NewFileSink(path)
+	if err != nil {
+		t.Fatalf("NewFileSink() error = %v", err)
+	}
+
+	for _, id := range []string{"1", "2"} {
+		if err = sink.Publish(context.Background(), &CloudEvent{Id: id}); err != nil {
+			t.Fatalf("Publish() error = %v", err)
+		}
+	}
+	_ = sink.Close()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	var ids []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		e := &CloudEvent{}
+		if err = json.Unmarshal(scanner.Bytes(), e); err != nil {
+			t.Fatal(err)
+		}
+		ids = append(ids, e.Id)
+	}
+
+	if len(ids) != 2 || ids[0] != "1" || ids[1] != "2" {
+		t.Errorf("ids = %v, want [1 2]", ids)
+	}
+}
+
+// sinkFunc 函数形式的投递目标
+type sinkFunc func(ctx context.Context, e *CloudEvent) error
+
+// Publish 投递事件
+func (f sinkFunc) Publish(ctx context.Context, e *CloudEvent) error {
+	return f(ctx, e)
+}
+
+// waitPublished 等待后台协程投递指定数量的事件
+func waitPublished(t *testing.T, ch chan *CloudEvent, n int) {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for len(ch) < n {
+		if time.Now().After(deadline) {
+			t.Fatalf("published = %d, want %d", len(ch), n)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
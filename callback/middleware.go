@@ -0,0 +1,290 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 回调事件处理中间件
+ */
+
+package callback
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/d60-Lab/tencent-im/internal/core"
+)
+
+const (
+	defaultRecoveryMessage = "callback handler panic"
+	defaultBeforeTimeout   = 1500 * time.Millisecond // 腾讯云IM后台回调超时时间约为2秒，预留网络传输时间
+)
+
+type (
+	// TimeoutOptions 事件处理超时配置
+	TimeoutOptions struct {
+		Timeout time.Duration                // （选填）所有事件的处理超时时间，为0时仅发生之前回调使用默认超时时间
+		Events  map[Event]time.Duration      // （选填）按事件设置的处理超时时间，优先于 Timeout
+		Answer  func(ack Ack, event Event)   // （选填）处理超时后的默认应答，默认应答成功
+		OnLate  func(event Event, err error) // （选填）超时后处理器执行完成（或发生异常）时的通知
+	}
+
+	// recordAck 记录应答结果的应答器
+	recordAck struct {
+		ack    Ack
+		mu     sync.Mutex
+		acked  bool
+		failed bool
+	}
+
+	// timeoutAck 超时后拒绝后续应答的应答器
+	timeoutAck struct {
+		ack      Ack
+		mu       sync.Mutex
+		deadline time.Time
+		expired  bool
+	}
+)
+
+// Recovery 异常恢复中间件
+// 事件处理器发生 panic 时应答失败，message 为失败应答的错误信息
+func Recovery(message ...string) Middleware {
+	msg := defaultRecoveryMessage
+	if len(message) > 0 {
+		msg = message[0]
+	}
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, event Event, ack Ack, data interface{}) {
+			defer func() {
+				if r := recover(); r != nil {
+					_ = ack.AckFailure(msg)
+				}
+			}()
+
+			next(ctx, event, ack, data)
+		}
+	}
+}
+
+// Timeout 事件处理超时中间件
+// 事件处理器在超时时间内未返回时自动执行默认应答，处理器后续的应答将被忽略
+// 处理器收到的 ctx 在超时后被取消，处理器应据此尽快退出
+// 处理器在超时前发生的 panic 会在当前调用链中重新抛出，以便外层的 Recovery 中间件处理
+func Timeout(opt ...TimeoutOptions) Middleware {
+	o := TimeoutOptions{}
+	if len(opt) > 0 {
+		o = opt[0]
+	}
+
+	if o.Answer == nil {
+		o.Answer = func(ack Ack, event Event) {
+			_ = ack.AckSuccess(ackSuccessCode)
+		}
+	}
+
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, event Event, ack Ack, data interface{}) {
+			timeout := o.timeout(event)
+			if timeout <= 0 {
+				next(ctx, event, ack, data)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+
+			deadline, _ := ctx.Deadline()
+			ta := &timeoutAck{ack: ack, deadline: deadline}
+			done := make(chan interface{}, 1)
+			go func() {
+				defer func() {
+					done <- recover()
+				}()
+
+				next(ctx, event, ta, data)
+			}()
+
+			select {
+			case r := <-done:
+				if r != nil {
+					panic(r)
+				}
+			case <-ctx.Done():
+				ta.expire()
+				o.Answer(ack, event)
+
+				if o.OnLate != nil {
+					go func() {
+						if r := <-done; r != nil {
+							o.OnLate(event, fmt.Errorf("callback handler panic: %v", r))
+						} else {
+							o.OnLate(event, nil)
+						}
+					}()
+				}
+			}
+		}
+	}
+}
+
+// timeout 获取事件处理超时时间
+func (o TimeoutOptions) timeout(event Event) time.Duration {
+	if t, ok := o.Events[event]; ok {
+		return t
+	}
+
+	if o.Timeout > 0 {
+		return o.Timeout
+	}
+
+	if event.IsBefore() {
+		return defaultBeforeTimeout
+	}
+
+	return 0
+}
+
+// Logging 日志中间件
+// 记录每次回调的事件、回调命令、处理耗时及应答结果
+func Logging(logger core.Logger) Middleware {
+	return func(next HandlerFunc) HandlerFunc {
+		return func(ctx context.Context, event Event, ack Ack, data interface{}) {
+			var (
+				start = time.Now()
+				ra    = &recordAck{ack: ack}
+			)
+
+			command := event.String()
+			if u, ok := data.(*UnknownEvent); ok {
+				command = u.CallbackCommand
+			}
+
+			defer func() {
+				acked, failed := ra.result()
+				fields := map[string]interface{}{
+					"event":   int(event),
+					"command": command,
+					"latency": time.Since(start).String(),
+					"acked":   acked,
+				}
+				if info, ok := RequestInfoFromContext(ctx); ok {
+					fields["client_ip"] = info.ClientIP
+					fields["platform"] = info.OptPlatform
+				}
+
+				if r := recover(); r != nil {
+					fields["panic"] = r
+					logger.Error(ctx, "Callback handler panic", fields)
+					panic(r)
+				}
+
+				if failed {
+					logger.Warn(ctx, "Callback handled with failure", fields)
+				} else {
+					logger.Debug(ctx, "Callback handled", fields)
+				}
+			}()
+
+			next(ctx, event, ra, data)
+		}
+	}
+}
+
+// Ack 应答
+func (a *recordAck) Ack(resp interface{}) error {
+	err := a.ack.Ack(resp)
+	a.record(err, false)
+	return err
+}
+
+// AckFailure 失败应答
+func (a *recordAck) AckFailure(message ...string) error {
+	err := a.ack.AckFailure(message...)
+	a.record(err, true)
+	return err
+}
+
+// AckSuccess 成功应答
+func (a *recordAck) AckSuccess(code int, message ...string) error {
+	err := a.ack.AckSuccess(code, message...)
+	a.record(err, false)
+	return err
+}
+
+// Unwrap 获取被包装的应答器
+func (a *recordAck) Unwrap() Ack {
+	return a.ack
+}
+
+// record 记录应答结果
+func (a *recordAck) record(err error, failed bool) {
+	if err != nil {
+		return
+	}
+
+	a.mu.Lock()
+	a.acked, a.failed = true, failed
+	a.mu.Unlock()
+}
+
+// result 获取应答结果
+func (a *recordAck) result() (acked, failed bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	return a.acked, a.failed
+}
+
+// Ack 应答（超时后返回 errAlreadyAcked）
+func (a *timeoutAck) Ack(resp interface{}) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.isExpired() {
+		return errAlreadyAcked
+	}
+
+	return a.ack.Ack(resp)
+}
+
+// AckFailure 失败应答
+func (a *timeoutAck) AckFailure(message ...string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.isExpired() {
+		return errAlreadyAcked
+	}
+
+	return a.ack.AckFailure(message...)
+}
+
+// AckSuccess 成功应答
+func (a *timeoutAck) AckSuccess(code int, message ...string) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	if a.isExpired() {
+		return errAlreadyAcked
+	}
+
+	return a.ack.AckSuccess(code, message...)
+}
+
+// Unwrap 获取被包装的应答器
+func (a *timeoutAck) Unwrap() Ack {
+	return a.ack
+}
+
+// isExpired 是否已超时（调用方需持有锁）
+// 处理器可能先于超时分支感知到 ctx 取消，因此同时以截止时间判断
+func (a *timeoutAck) isExpired() bool {
+	return a.expired || !time.Now().Before(a.deadline)
+}
+
+// expire 标记处理已超时，之后处理器的应答将被忽略
+func (a *timeoutAck) expire() {
+	a.mu.Lock()
+	a.expired = true
+	a.mu.Unlock()
+}
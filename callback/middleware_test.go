@@ -0,0 +1,202 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 回调事件处理中间件单元测试
+ */
+
+package callback
+
+import (
+	"context"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+type testLogger struct {
+	mu      sync.Mutex
+	entries []map[string]interface{}
+}
+
+func (l *testLogger) log(fields map[string]interface{}) {
+	l.mu.Lock()
+	l.entries = append(l.entries, fields)
+	l.mu.Unlock()
+}
+
+func (l *testLogger) Debug(ctx context.Context, msg string, fields map[string]interface{}) {
+	l.log(fields)
+}
+func (l *testLogger) Info(ctx context.Context, msg string, fields map[string]interface{}) {
+	l.log(fields)
+}
+func (l *testLogger) Warn(ctx context.Context, msg string, fields map[string]interface{}) {
+	l.log(fields)
+}
+func (l *testLogger) Error(ctx context.Context, msg string, fields map[string]interface{}) {
+	l.log(fields)
+}
+
+func TestCallback_UseOrder(t *testing.T) {
+	var order []string
+
+	trace := func(name string) Middleware {
+		return func(next HandlerFunc) HandlerFunc {
+			return func(ctx context.Context, event Event, ack Ack, data interface{}) {
+				order = append(order, name)
+				next(ctx, event, ack, data)
+			}
+		}
+	}
+
+	c := NewCallback(testAppId)
+	c.Use(trace("first"), trace("second"))
+	c.Register(EventAfterFriendAdd, func(ack Ack, data interface{}) {
+		order = append(order, "handler")
+		_ = ack.AckSuccess(ackSuccessCode)
+	})
+
+	c.Listen(httptest.NewRecorder(), newTestRequest(commandAfterFriendAdd, `{}`))
+
+	want := []string{"first", "second", "handler"}
+	if len(order) != len(want) {
+		t.Fatalf("order = %v, want %v", order, want)
+	}
+	for i := range want {
+		if order[i] != want[i] {
+			t.Fatalf("order = %v, want %v", order, want)
+		}
+	}
+}
+
+func TestRecovery(t *testing.T) {
+	c := NewCallback(testAppId)
+	c.Use(Recovery("handler crashed"))
+	c.Register(EventAfterFriendAdd, func(ack Ack, data interface{}) {
+		panic("boom")
+	})
+
+	w := httptest.NewRecorder()
+	c.Listen(w, newTestRequest(commandAfterFriendAdd, `{}`))
+
+	got := decodeTestResp(t, w)
+	if got.ActionStatus != ackFailureStatus || got.ErrorInfo != "handler crashed" {
+		t.Errorf("ack = %+v, want failure with configured message", got)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	t.Run("slow handler", func(t *testing.T) {
+		late := make(chan error, 1)
+		release := make(chan struct{})
+
+		c := NewCallback(testAppId)
+		c.Use(Timeout(TimeoutOptions{
+			Events: map[Event]time.Duration{EventBeforeFriendAdd: 20 * time.Millisecond},
+			OnLate: func(event Event, err error) { late <- err },
+		}))
+		c.Register(EventBeforeFriendAdd, func(ack Ack, data interface{}) {
+			<-release
+			_ = ack.AckFailure("too late")
+		})
+
+		w := httptest.NewRecorder()
+		c.Listen(w, newTestRequest(commandBeforeFriendAdd, `{}`))
+		close(release)
+
+		if got := decodeTestResp(t, w); got.ActionStatus != ackSuccessStatus {
+			t.Errorf("ActionStatus = %v, want %v", got.ActionStatus, ackSuccessStatus)
+		}
+
+		select {
+		case err := <-late:
+			if err != nil {
+				t.Errorf("OnLate err = %v, want nil", err)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("OnLate was not called")
+		}
+	})
+
+	t.Run("late ack after custom answer", func(t *testing.T) {
+		result := make(chan error, 1)
+
+		c := NewCallback(testAppId)
+		c.Use(Timeout(TimeoutOptions{
+			Events: map[Event]time.Duration{EventBeforeFriendAdd: 20 * time.Millisecond},
+			Answer: func(ack Ack, event Event) {},
+		}))
+		c.RegisterContext(EventBeforeFriendAdd, func(ctx context.Context, ack Ack, data interface{}) {
+			<-ctx.Done()
+			result <- ack.AckFailure("too late")
+		})
+
+		w := httptest.NewRecorder()
+		c.Listen(w, newTestRequest(commandBeforeFriendAdd, `{}`))
+
+		select {
+		case err := <-result:
+			if err != errAlreadyAcked {
+				t.Errorf("late ack err = %v, want %v", err, errAlreadyAcked)
+			}
+		case <-time.After(time.Second):
+			t.Fatal("handler context was not cancelled")
+		}
+
+		if w.Body.Len() != 0 {
+			t.Errorf("body = %q, want empty", w.Body.String())
+		}
+	})
+
+	t.Run("panic before deadline", func(t *testing.T) {
+		c := NewCallback(testAppId)
+		c.Use(Recovery(), Timeout())
+		c.Register(EventBeforeFriendAdd, func(ack Ack, data interface{}) {
+			panic("boom")
+		})
+
+		w := httptest.NewRecorder()
+		c.Listen(w, newTestRequest(commandBeforeFriendAdd, `{}`))
+
+		if got := decodeTestResp(t, w); got.ActionStatus != ackFailureStatus {
+			t.Errorf("ActionStatus = %v, want %v", got.ActionStatus, ackFailureStatus)
+		}
+	})
+
+	t.Run("after events without deadline", func(t *testing.T) {
+		if d := (TimeoutOptions{}).timeout(EventAfterFriendAdd); d != 0 {
+			t.Errorf("timeout = %v, want 0", d)
+		}
+		if d := (TimeoutOptions{}).timeout(EventBeforeGroupMessageSend); d != defaultBeforeTimeout {
+			t.Errorf("timeout = %v, want %v", d, defaultBeforeTimeout)
+		}
+	})
+}
+
+func TestLogging(t *testing.T) {
+	logger := &testLogger{}
+
+	c := NewCallback(testAppId)
+	c.Use(Logging(logger))
+	c.Register(EventAfterFriendAdd, func(ack Ack, data interface{}) {
+		_ = ack.AckSuccess(ackSuccessCode)
+	})
+
+	c.Listen(httptest.NewRecorder(), newTestRequest(commandAfterFriendAdd, `{}`))
+
+	if len(logger.entries) != 1 {
+		t.Fatalf("log entries = %d, want 1", len(logger.entries))
+	}
+
+	fields := logger.entries[0]
+	if fields["command"] != commandAfterFriendAdd {
+		t.Errorf("command = %v, want %v", fields["command"], commandAfterFriendAdd)
+	}
+	if fields["acked"] != true {
+		t.Errorf("acked = %v, want true", fields["acked"])
+	}
+	if _, ok := fields["latency"]; !ok {
+		t.Error("latency field is missing")
+	}
+}
@@ -0,0 +1,351 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 回调事件持久化队列
+ */
+
+package callback
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/d60-Lab/tencent-im/internal/core"
+	"github.com/d60-Lab/tencent-im/internal/random"
+)
+
+const (
+	queueFileExt       = ".json"
+	queueDeadSubDir    = "dead"
+	queueCorruptSubDir = "corrupt"
+)
+
+type (
+	// QueueItem 待异步处理的回调事件
+	QueueItem struct {
+		Id            string          `json:"Id"`                  // 事件ID
+		Command       string          `json:"Command"`             // 回调命令
+		Query         string          `json:"Query,omitempty"`     // 回调请求的查询参数
+		Body          json.RawMessage `json:"Body"`                // 原始回调数据
+		Attempts      int             `json:"Attempts"`            // 已处理次数
+		LastError     string          `json:"LastError,omitempty"` // 最后一次处理失败的错误信息
+		CreatedAt     int64           `json:"CreatedAt"`           // 入队时间，单位为毫秒
+		NextAttemptAt int64           `json:"NextAttemptAt"`       // 下次允许处理的时间，单位为毫秒
+	}
+
+	// Queue 回调事件队列
+	Queue interface {
+		// Push 事件入队
+		Push(item *QueueItem) error
+		// Pop 取出一个可处理的事件，没有可处理的事件时返回 nil
+		// 取出的事件须通过 Done、Retry 或 Bury 结束处理，否则不会再被取出
+		Pop() (*QueueItem, error)
+		// Done 事件处理完成，从队列中移除
+		Done(item *QueueItem) error
+		// Retry 事件处理失败，放回队列等待重试
+		Retry(item *QueueItem) error
+		// Bury 事件多次处理失败，移入死信列表
+		Bury(item *QueueItem) error
+		// DeadLetters 获取死信列表
+		DeadLetters() ([]*QueueItem, error)
+	}
+
+	// fileQueue 基于本地文件的回调事件队列（每个事件一个文件）
+	// 队列在内存中维护按入队顺序排列的事件索引，取出事件时仅读取候选事件的文件
+	fileQueue struct {
+		dir        string
+		deadDir    string
+		corruptDir string
+		logger     core.Logger
+		mu         sync.Mutex
+		ids        []string         // 按入队顺序排列的事件ID
+		next       map[string]int64 // 事件ID及其下次允许处理的时间，0表示未知或立即可处理
+		inflight   map[string]bool
+	}
+)
+
+// newQueueItem 新建待异步处理的回调事件
+func newQueueItem(command string, body []byte) *QueueItem {
+	now := time.Now()
+
+	return &QueueItem{
+		Id:        fmt.Sprintf("%019d-%s", now.UnixNano(), random.GenStr(random.AlphaLowerStr, 6)),
+		Command:   command,
+		Body:      body,
+		CreatedAt: now.UnixNano() / int64(time.Millisecond),
+	}
+}
+
+// NewFileQueue 新建基于本地文件的回调事件队列
+// 事件以独立文件的形式保存在 dir 目录中，死信保存在 dir/dead 目录中，进程重启后未处理完成的事件将继续处理
+// 无法读取或解析的事件文件将被移至 dir/dead/corrupt 目录并通过 logger 记录，不会阻塞后续事件的处理
+// 队列目录仅供单个进程使用，其他进程写入的文件在重启前不会被处理
+func NewFileQueue(dir string, logger ...core.Logger) (Queue, error) {
+	q := &fileQueue{
+		dir:        dir,
+		deadDir:    filepath.Join(dir, queueDeadSubDir),
+		corruptDir: filepath.Join(dir, queueDeadSubDir, queueCorruptSubDir),
+		next:       make(map[string]int64),
+		inflight:   make(map[string]bool),
+	}
+
+	if len(logger) > 0 && logger[0] != nil {
+		q.logger = logger[0]
+	} else {
+		q.logger = core.NewDefaultLogger()
+	}
+
+	if err := os.MkdirAll(q.corruptDir, 0755); err != nil {
+		return nil, err
+	}
+
+	names, err := q.list(q.dir)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range names {
+		id := strings.TrimSuffix(name, queueFileExt)
+		q.ids = append(q.ids, id)
+		q.next[id] = 0
+	}
+
+	return q, nil
+}
+
+// Push 事件入队
+func (q *fileQueue) Push(item *QueueItem) error {
+	if err := q.write(q.dir, item); err != nil {
+		return err
+	}
+
+	q.mu.Lock()
+	q.index(item)
+	q.mu.Unlock()
+
+	return nil
+}
+
+// Pop 取出一个可处理的事件
+func (q *fileQueue) Pop() (*QueueItem, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+	for i := 0; i < len(q.ids); i++ {
+		id := q.ids[i]
+		if q.inflight[id] || q.next[id] > now {
+			continue
+		}
+
+		item, err := q.read(filepath.Join(q.dir, id+queueFileExt))
+		if err != nil {
+			if !os.IsNotExist(err) {
+				q.quarantine(id, err)
+			}
+			q.unindex(id)
+			i--
+			continue
+		}
+
+		if item.NextAttemptAt > now {
+			q.next[id] = item.NextAttemptAt
+			continue
+		}
+
+		q.inflight[id] = true
+		return item, nil
+	}
+
+	return nil, nil
+}
+
+// Done 事件处理完成
+func (q *fileQueue) Done(item *QueueItem) error {
+	defer q.release(item, true)
+
+	if err := os.Remove(q.path(q.dir, item)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// Retry 事件放回队列等待重试
+func (q *fileQueue) Retry(item *QueueItem) error {
+	if err := q.write(q.dir, item); err != nil {
+		q.release(item, false)
+		return err
+	}
+
+	q.mu.Lock()
+	q.next[item.Id] = item.NextAttemptAt
+	delete(q.inflight, item.Id)
+	q.mu.Unlock()
+
+	return nil
+}
+
+// Bury 事件移入死信列表
+func (q *fileQueue) Bury(item *QueueItem) error {
+	defer q.release(item, true)
+
+	if err := q.write(q.deadDir, item); err != nil {
+		return err
+	}
+
+	if err := os.Remove(q.path(q.dir, item)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+// DeadLetters 获取死信列表
+func (q *fileQueue) DeadLetters() ([]*QueueItem, error) {
+	names, err := q.list(q.deadDir)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*QueueItem, 0, len(names))
+	for _, name := range names {
+		item, err := q.read(filepath.Join(q.deadDir, name))
+		if err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	return items, nil
+}
+
+// release 释放处理中的事件，remove 为 true 时同时从索引中移除
+func (q *fileQueue) release(item *QueueItem, remove bool) {
+	q.mu.Lock()
+	delete(q.inflight, item.Id)
+	if remove {
+		q.unindex(item.Id)
+	}
+	q.mu.Unlock()
+}
+
+// index 将事件按入队顺序加入索引（调用方需持有锁）
+func (q *fileQueue) index(item *QueueItem) {
+	if _, ok := q.next[item.Id]; ok {
+		q.next[item.Id] = item.NextAttemptAt
+		return
+	}
+
+	i := sort.SearchStrings(q.ids, item.Id)
+	q.ids = append(q.ids, "")
+	copy(q.ids[i+1:], q.ids[i:])
+	q.ids[i] = item.Id
+	q.next[item.Id] = item.NextAttemptAt
+}
+
+// unindex 从索引中移除事件（调用方需持有锁）
+func (q *fileQueue) unindex(id string) {
+	if _, ok := q.next[id]; !ok {
+		return
+	}
+	delete(q.next, id)
+
+	if i := sort.SearchStrings(q.ids, id); i < len(q.ids) && q.ids[i] == id {
+		q.ids = append(q.ids[:i], q.ids[i+1:]...)
+	}
+}
+
+// quarantine 将无法读取或解析的事件文件移至隔离目录
+func (q *fileQueue) quarantine(id string, cause error) {
+	name := id + queueFileExt
+	fields := map[string]interface{}{
+		"file":  filepath.Join(q.dir, name),
+		"error": cause.Error(),
+	}
+
+	if err := os.Rename(filepath.Join(q.dir, name), filepath.Join(q.corruptDir, name)); err != nil && !os.IsNotExist(err) {
+		fields["quarantine_error"] = err.Error()
+	}
+
+	q.logger.Error(context.Background(), "Callback queue file is corrupt and has been quarantined", fields)
+}
+
+// path 获取事件文件路径
+func (q *fileQueue) path(dir string, item *QueueItem) string {
+	return filepath.Join(dir, item.Id+queueFileExt)
+}
+
+// list 按入队顺序列出目录中的事件文件
+func (q *fileQueue) list(dir string) ([]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), queueFileExt) {
+			names = append(names, entry.Name())
+		}
+	}
+	sort.Strings(names)
+
+	return names, nil
+}
+
+// read 读取事件文件
+func (q *fileQueue) read(file string) (*QueueItem, error) {
+	b, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	item := &QueueItem{}
+	if err = json.Unmarshal(b, item); err != nil {
+		return nil, err
+	}
+
+	return item, nil
+}
+
+// write 写入事件文件（先写临时文件再重命名，避免写入中断产生不完整的文件）
+func (q *fileQueue) write(dir string, item *QueueItem) error {
+	b, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+
+	if _, err = tmp.Write(b); err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	if err = os.Rename(tmp.Name(), q.path(dir, item)); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	return nil
+}
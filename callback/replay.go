@@ -0,0 +1,216 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 归档回调回放实现
+ */
+
+package callback
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+type (
+	// ReplayOptions 回放配置
+	ReplayOptions struct {
+		Dir      string                                 // （选填）归档目录，默认为 callback_archive
+		Prefix   string                                 // （选填）归档文件名前缀，默认为 callback
+		Files    []string                               // （选填）指定回放的归档文件，设置后忽略 Dir 及 Prefix
+		Start    time.Time                              // （选填）回放的起始接收时间（包含），默认不限
+		End      time.Time                              // （选填）回放的截止接收时间（不包含），默认不限
+		Events   []Event                                // （选填）回放的事件，默认回放全部事件，SDK暂不支持的回调命令对应 EventUnknown
+		OnResult func(record *ArchiveRecord, err error) // （选填）单条记录回放完成后的回调，err 为处理器失败应答或 panic 信息
+	}
+
+	// ReplayResult 回放结果
+	ReplayResult struct {
+		Total    int // 读取的记录数
+		Skipped  int // 被过滤的记录数
+		Replayed int // 处理成功的记录数
+		Failed   int // 处理失败的记录数
+	}
+)
+
+// Replay 回放已归档的回调
+// 记录按归档顺序依次交由中间件及处理器处理，不经过异步队列及归档，应答仅用于统计处理结果
+// 处理器可通过 RequestInfo.Replay 判断当前事件是否为回放
+func (c *callback) Replay(ctx context.Context, opt ReplayOptions) (*ReplayResult, error) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ret := &ReplayResult{}
+	err := readArchive(opt, func(record *ArchiveRecord) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		err := c.handleDetached(ctx, record.Command, record.Query, record.RawBody(), true)
+		if err != nil {
+			ret.Failed++
+		} else {
+			ret.Replayed++
+		}
+
+		if opt.OnResult != nil {
+			opt.OnResult(record, err)
+		}
+
+		return nil
+	}, func(skipped bool) {
+		ret.Total++
+		if skipped {
+			ret.Skipped++
+		}
+	})
+
+	return ret, err
+}
+
+// ReadArchive 按归档顺序读取符合过滤条件的归档记录，fn 返回错误时停止读取并返回该错误
+func ReadArchive(opt ReplayOptions, fn func(record *ArchiveRecord) error) error {
+	return readArchive(opt, fn, func(bool) {})
+}
+
+// readArchive 读取归档记录，每读取一条记录调用一次 count，skipped 表示该记录是否被过滤
+func readArchive(opt ReplayOptions, fn func(record *ArchiveRecord) error, count func(skipped bool)) error {
+	files, err := archiveFiles(opt)
+	if err != nil {
+		return err
+	}
+
+	var events map[Event]bool
+	if len(opt.Events) > 0 {
+		events = make(map[Event]bool, len(opt.Events))
+		for _, event := range opt.Events {
+			events[event] = true
+		}
+	}
+
+	for _, file := range files {
+		err = readArchiveFile(file, func(record *ArchiveRecord) error {
+			if !opt.match(record, events) {
+				count(true)
+				return nil
+			}
+
+			count(false)
+			return fn(record)
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// RawBody 获取原始请求体
+// 未记录 BodyEncoding 的旧归档记录，以JSON字符串开头的请求体视为非JSON请求体
+func (r *ArchiveRecord) RawBody() []byte {
+	switch r.BodyEncoding {
+	case ArchiveBodyJSON:
+		return r.Body
+	case ArchiveBodyRaw:
+	default:
+		if len(r.Body) == 0 || r.Body[0] != '"' {
+			return r.Body
+		}
+	}
+
+	var s string
+	if err := json.Unmarshal(r.Body, &s); err != nil {
+		return r.Body
+	}
+
+	return []byte(s)
+}
+
+// match 判断记录是否符合过滤条件
+func (o ReplayOptions) match(record *ArchiveRecord, events map[Event]bool) bool {
+	receivedAt := time.UnixMilli(record.ReceivedAt)
+	if !o.Start.IsZero() && receivedAt.Before(o.Start) {
+		return false
+	}
+	if !o.End.IsZero() && !receivedAt.Before(o.End) {
+		return false
+	}
+
+	if events != nil {
+		event, _ := ParseEvent(record.Command)
+		if !events[event] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// archiveFiles 获取待回放的归档文件
+func archiveFiles(opt ReplayOptions) ([]string, error) {
+	if len(opt.Files) > 0 {
+		return opt.Files, nil
+	}
+
+	if opt.Dir == "" {
+		opt.Dir = defaultArchiveDir
+	}
+	if opt.Prefix == "" {
+		opt.Prefix = defaultArchivePrefix
+	}
+
+	entries, err := os.ReadDir(opt.Dir)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasPrefix(name, opt.Prefix+"-") || !strings.HasSuffix(name, archiveFileExt) {
+			continue
+		}
+		files = append(files, filepath.Join(opt.Dir, name))
+	}
+
+	sort.Strings(files)
+
+	return files, nil
+}
+
+// readArchiveFile 逐行读取归档文件，无法解析的行将被忽略
+func readArchiveFile(path string, fn func(record *ArchiveRecord) error) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			record := &ArchiveRecord{}
+			if json.Unmarshal(line, record) == nil && record.Command != "" {
+				if err := fn(record); err != nil {
+					return err
+				}
+			}
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+	}
+}
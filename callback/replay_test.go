@@ -0,0 +1,214 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 回调归档及回放单元测试
+ */
+
+package callback
+
+import (
+	"context"
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestFileArchiver(t *testing.T) {
+	dir := t.TempDir()
+
+	archiver, err := NewFileArchiver(ArchiveOptions{Dir: dir, MaxSize: 1})
+	if err != nil {
+		t.Fatalf("NewFileArchiver() error = %v", err)
+	}
+	defer archiver.Close()
+
+	c := NewCallbackWithOptions(Options{SdkAppId: testAppId, Archiver: archiver})
+	c.Register(EventAfterFriendAdd, func(ack Ack, data interface{}) {
+		_ = ack.AckFailure("handler bug")
+	})
+
+	c.Listen(httptest.NewRecorder(), newTestRequest(commandAfterFriendAdd, `{"PairList":[]}`))
+	c.Listen(httptest.NewRecorder(), newTestRequest(commandAfterGroupFull, `{"GroupId":"@TGS#1"}`))
+
+	files, err := archiveFiles(ReplayOptions{Dir: dir})
+	if err != nil {
+		t.Fatalf("archiveFiles() error = %v", err)
+	}
+	if len(files) != 2 {
+		t.Fatalf("files = %v, want 2 rotated files", files)
+	}
+
+	var records []*ArchiveRecord
+	_ = ReadArchive(ReplayOptions{Dir: dir}, func(record *ArchiveRecord) error {
+		records = append(records, record)
+		return nil
+	})
+
+	if len(records) != 2 {
+		t.Fatalf("records = %d, want 2", len(records))
+	}
+	if records[0].Command != commandAfterFriendAdd || string(records[0].Body) != `{"PairList":[]}` {
+		t.Errorf("record = %+v", records[0])
+	}
+	if string(records[0].Ack) != `{"ErrorCode":1,"ErrorInfo":"handler bug","ActionStatus":"FAIL"}` {
+		t.Errorf("Ack = %s", records[0].Ack)
+	}
+	if records[0].ReceivedAt == 0 || records[0].Query == "" {
+		t.Errorf("record = %+v, want received time and query", records[0])
+	}
+}
+
+func TestCallback_Replay(t *testing.T) {
+	dir := t.TempDir()
+
+	archiver, err := NewFileArchiver(ArchiveOptions{Dir: dir})
+	if err != nil {
+		t.Fatalf("NewFileArchiver() error = %v", err)
+	}
+
+	now := time.Now().UnixMilli()
+	records := []*ArchiveRecord{
+		{Command: commandAfterGroupFull, Body: []byte(`{"GroupId":"@TGS#1"}`), ReceivedAt: now - 3000},
+		{Command: commandAfterGroupFull, Body: []byte(`{"GroupId":"@TGS#2"}`), ReceivedAt: now - 2000},
+		{Command: commandAfterFriendAdd, Body: []byte(`{}`), ReceivedAt: now - 2000},
+		{Command: commandAfterGroupFull, Body: []byte(`{"GroupId":"@TGS#3"}`), ReceivedAt: now - 1000},
+	}
+	for _, record := range records {
+		if err = archiver.Archive(record); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_ = archiver.Close()
+
+	var groups []string
+	c := NewCallback(testAppId)
+	c.RegisterContext(EventAfterGroupFull, func(ctx context.Context, ack Ack, data interface{}) {
+		if info, ok := RequestInfoFromContext(ctx); !ok || !info.Replay {
+			t.Error("RequestInfo.Replay = false, want true")
+		}
+
+		groupId := data.(*AfterGroupFull).GroupId
+		groups = append(groups, groupId)
+		if groupId == "@TGS#3" {
+			_ = ack.AckFailure("still broken")
+			return
+		}
+		_ = ack.AckSuccess(ackSuccessCode)
+	})
+
+	ret, err := c.Replay(context.Background(), ReplayOptions{
+		Dir:    dir,
+		Start:  time.UnixMilli(now - 2500),
+		Events: []Event{EventAfterGroupFull},
+	})
+	if err != nil {
+		t.Fatalf("Replay() error = %v", err)
+	}
+
+	want := ReplayResult{Total: 4, Skipped: 2, Replayed: 1, Failed: 1}
+	if *ret != want {
+		t.Errorf("Replay() = %+v, want %+v", *ret, want)
+	}
+	if len(groups) != 2 || groups[0] != "@TGS#2" || groups[1] != "@TGS#3" {
+		t.Errorf("groups = %v, want [@TGS#2 @TGS#3]", groups)
+	}
+}
+
+func TestCallback_ReplayHeader(t *testing.T) {
+	dir := t.TempDir()
+
+	archiver, err := NewFileArchiver(ArchiveOptions{Dir: dir})
+	if err != nil {
+		t.Fatalf("NewFileArchiver() error = %v", err)
+	}
+	defer archiver.Close()
+
+	var replays []bool
+	c := NewCallbackWithOptions(Options{SdkAppId: testAppId, Archiver: archiver, ReplayToken: "secret"})
+	c.RegisterContext(EventAfterGroupFull, func(ctx context.Context, ack Ack, data interface{}) {
+		info, _ := RequestInfoFromContext(ctx)
+		replays = append(replays, info.Replay)
+		_ = ack.AckSuccess(ackSuccessCode)
+	})
+
+	for _, token := range []string{"secret", "guess", ""} {
+		r := newTestRequest(commandAfterGroupFull, `{"GroupId":"@TGS#1"}`)
+		if token != "" {
+			r.Header.Set(ReplayHeader, token)
+		}
+		c.Listen(httptest.NewRecorder(), r)
+	}
+
+	if len(replays) != 3 || !replays[0] || replays[1] || replays[2] {
+		t.Errorf("replays = %v, want [true false false]", replays)
+	}
+
+	var archived int
+	_ = ReadArchive(ReplayOptions{Dir: dir}, func(record *ArchiveRecord) error {
+		archived++
+		return nil
+	})
+	if archived != 2 {
+		t.Errorf("archived = %d, want 2", archived)
+	}
+}
+
+func TestArchiveRecord_RawBody(t *testing.T) {
+	tests := []struct {
+		name   string
+		record ArchiveRecord
+		want   string
+	}{
+		{"json", ArchiveRecord{Body: []byte(`{"a":1}`), BodyEncoding: ArchiveBodyJSON}, `{"a":1}`},
+		{"json string", ArchiveRecord{Body: []byte(`"abc"`), BodyEncoding: ArchiveBodyJSON}, `"abc"`},
+		{"raw", ArchiveRecord{Body: []byte(`"a=1&b=2"`), BodyEncoding: ArchiveBodyRaw}, `a=1&b=2`},
+		{"legacy raw", ArchiveRecord{Body: []byte(`"a=1"`)}, `a=1`},
+		{"legacy json", ArchiveRecord{Body: []byte(`{"a":1}`)}, `{"a":1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := string(tt.record.RawBody()); got != tt.want {
+				t.Errorf("RawBody() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// archiverFunc 以函数实现的归档器
+type archiverFunc func(record *ArchiveRecord) error
+
+func (f archiverFunc) Archive(record *ArchiveRecord) error {
+	return f(record)
+}
+
+func TestCallback_ArchiveError(t *testing.T) {
+	diskFull := errors.New("no space left on device")
+
+	var (
+		failed *ArchiveRecord
+		got    error
+	)
+	c := NewCallbackWithOptions(Options{
+		SdkAppId: testAppId,
+		Archiver: archiverFunc(func(record *ArchiveRecord) error { return diskFull }),
+		Logger:   &testLogger{},
+		OnArchiveError: func(record *ArchiveRecord, err error) {
+			failed, got = record, err
+		},
+	})
+	c.Register(EventAfterGroupFull, func(ack Ack, data interface{}) {
+		_ = ack.AckSuccess(ackSuccessCode)
+	})
+
+	w := httptest.NewRecorder()
+	c.Listen(w, newTestRequest(commandAfterGroupFull, `{"GroupId":"@TGS#1"}`))
+
+	if resp := decodeTestResp(t, w); resp.ActionStatus != ackSuccessStatus {
+		t.Errorf("ActionStatus = %v, want %v", resp.ActionStatus, ackSuccessStatus)
+	}
+	if got != diskFull || failed == nil || failed.Command != commandAfterGroupFull {
+		t.Errorf("OnArchiveError = %+v, %v, want the failed record and error", failed, got)
+	}
+}
@@ -0,0 +1,181 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 事件投递目标实现
+ */
+
+package callback
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const (
+	cloudEventsStructuredContentType = "application/cloudevents+json; charset=utf-8"
+
+	defaultHTTPSinkTimeout     = 5 * time.Second
+	defaultHTTPSinkMaxAttempts = 3
+	defaultHTTPSinkBackoff     = 200 * time.Millisecond
+)
+
+// ErrChannelSinkFull 通道已满，事件被丢弃
+var ErrChannelSinkFull = errors.New("callback channel sink is full")
+
+type (
+	// HTTPSinkOptions HTTP投递目标配置
+	HTTPSinkOptions struct {
+		Url         string            // （必填）接收事件的地址
+		Headers     map[string]string // （选填）附加的请求头
+		Client      *http.Client      // （选填）HTTP客户端，默认使用超时时间为5秒的客户端
+		MaxAttempts int               // （选填）最大投递次数，默认为3
+		Backoff     time.Duration     // （选填）首次重试的等待时间，之后每次重试翻倍，默认为200毫秒
+	}
+
+	// httpSink 以 CloudEvents 结构化模式 POST 至指定地址的投递目标
+	httpSink struct {
+		opt HTTPSinkOptions
+	}
+
+	// FileSink 以JSON Lines格式追加写入本地文件的投递目标
+	FileSink struct {
+		mu   sync.Mutex
+		file *os.File
+	}
+
+	// channelSink 进程内通道投递目标
+	channelSink struct {
+		ch chan<- *CloudEvent
+	}
+)
+
+// NewHTTPSink 新建HTTP投递目标
+// 网络错误、HTTP 429 及 5xx 应答将按退避策略重试
+func NewHTTPSink(opt HTTPSinkOptions) Sink {
+	if opt.Client == nil {
+		opt.Client = &http.Client{Timeout: defaultHTTPSinkTimeout}
+	}
+	if opt.MaxAttempts <= 0 {
+		opt.MaxAttempts = defaultHTTPSinkMaxAttempts
+	}
+	if opt.Backoff <= 0 {
+		opt.Backoff = defaultHTTPSinkBackoff
+	}
+
+	return &httpSink{opt: opt}
+}
+
+// Publish 投递事件
+func (s *httpSink) Publish(ctx context.Context, e *CloudEvent) error {
+	body, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	backoff := s.opt.Backoff
+	for attempt := 1; ; attempt++ {
+		retryable, err := s.post(ctx, body)
+		if err == nil || !retryable || attempt >= s.opt.MaxAttempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		backoff *= 2
+	}
+}
+
+// post 发送一次投递请求
+func (s *httpSink) post(ctx context.Context, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.opt.Url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	req.Header.Set("Content-Type", cloudEventsStructuredContentType)
+	for k, v := range s.opt.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := s.opt.Client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		return false, nil
+	}
+
+	retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+
+	return retryable, fmt.Errorf("callback sink %s responded with status %d", s.opt.Url, resp.StatusCode)
+}
+
+// NewFileSink 新建本地文件投递目标，文件不存在时自动创建，已存在时追加写入
+func NewFileSink(path string) (*FileSink, error) {
+	if dir := filepath.Dir(path); dir != "" {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, err
+		}
+	}
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileSink{file: file}, nil
+}
+
+// Publish 投递事件
+func (s *FileSink) Publish(ctx context.Context, e *CloudEvent) error {
+	b, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	_, err = s.file.Write(append(b, '\n'))
+
+	return err
+}
+
+// Close 关闭文件
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.file.Close()
+}
+
+// NewChannelSink 新建进程内通道投递目标，通道已满时不阻塞，丢弃事件并返回 ErrChannelSinkFull
+func NewChannelSink(ch chan<- *CloudEvent) Sink {
+	return &channelSink{ch: ch}
+}
+
+// Publish 投递事件
+func (s *channelSink) Publish(ctx context.Context, e *CloudEvent) error {
+	select {
+	case s.ch <- e:
+		return nil
+	default:
+		return ErrChannelSinkFull
+	}
+}
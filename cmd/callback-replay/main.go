@@ -0,0 +1,135 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 归档回调回放命令，将归档记录携带回放请求头重新投递至指定的回调地址并丢弃应答
+ *        服务端需配置相同的 callback.Options.ReplayToken，回放请求才会跳过异步队列、归档及去重
+ */
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/d60-Lab/tencent-im/callback"
+)
+
+func main() {
+	var (
+		dir     = flag.String("dir", "callback_archive", "归档目录")
+		prefix  = flag.String("prefix", "callback", "归档文件名前缀")
+		target  = flag.String("url", "", "回调地址，如 http://127.0.0.1:8080/callback")
+		token   = flag.String("token", os.Getenv("CALLBACK_REPLAY_TOKEN"), "回放令牌，需与服务端 callback.Options.ReplayToken 一致，默认读取环境变量 CALLBACK_REPLAY_TOKEN")
+		start   = flag.String("start", "", "起始接收时间（包含），RFC3339 格式")
+		end     = flag.String("end", "", "截止接收时间（不包含），RFC3339 格式")
+		events  = flag.String("events", "", "回放的回调命令，多个以逗号分隔，如 Group.CallbackAfterSendMsg")
+		dryRun  = flag.Bool("dry-run", false, "仅列出待回放的记录")
+		timeout = flag.Duration("timeout", 5*time.Second, "单次请求超时时间")
+	)
+	flag.Parse()
+
+	if !*dryRun {
+		if *target == "" {
+			exit(fmt.Errorf("-url is required"))
+		}
+		if *token == "" {
+			exit(fmt.Errorf("-token is required, replayed callbacks would otherwise be archived and deduplicated again"))
+		}
+	}
+
+	opt := callback.ReplayOptions{Dir: *dir, Prefix: *prefix}
+	opt.Start = parseTime(*start)
+	opt.End = parseTime(*end)
+
+	if *events != "" {
+		for _, command := range strings.Split(*events, ",") {
+			event, _ := callback.ParseEvent(strings.TrimSpace(command))
+			opt.Events = append(opt.Events, event)
+		}
+	}
+
+	client := &http.Client{Timeout: *timeout}
+
+	var replayed, failed int
+	err := callback.ReadArchive(opt, func(record *callback.ArchiveRecord) error {
+		receivedAt := time.UnixMilli(record.ReceivedAt).Format(time.RFC3339)
+
+		if *dryRun {
+			fmt.Printf("%s\t%s\n", receivedAt, record.Command)
+			return nil
+		}
+
+		if err := post(client, *target, *token, record); err != nil {
+			failed++
+			fmt.Fprintf(os.Stderr, "%s\t%s\t%v\n", receivedAt, record.Command, err)
+			return nil
+		}
+
+		replayed++
+		return nil
+	})
+	if err != nil {
+		exit(err)
+	}
+
+	if !*dryRun {
+		fmt.Printf("replayed: %d, failed: %d\n", replayed, failed)
+	}
+}
+
+// post 投递归档记录，应答内容将被丢弃
+func post(client *http.Client, target, token string, record *callback.ArchiveRecord) error {
+	u := target
+	if record.Query != "" {
+		if strings.Contains(u, "?") {
+			u += "&" + record.Query
+		} else {
+			u += "?" + record.Query
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodPost, u, bytes.NewReader(record.RawBody()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(callback.ReplayHeader, token)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// parseTime 解析时间参数
+func parseTime(value string) time.Time {
+	if value == "" {
+		return time.Time{}
+	}
+
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		exit(fmt.Errorf("invalid time %q: %w", value, err))
+	}
+
+	return t
+}
+
+func exit(err error) {
+	fmt.Fprintln(os.Stderr, err)
+	os.Exit(1)
+}
@@ -41,20 +41,18 @@ func main() {
 
 	// 注册回调事件
 	tim.Callback().Register(callback.EventAfterFriendAdd, func(ack callback.Ack, data interface{}) {
-		fmt.Printf("%+v", data.(callback.AfterFriendAdd))
+		fmt.Printf("%+v", data.(*callback.AfterFriendAdd))
 		_ = ack.AckSuccess(0)
 	})
 
 	// 注册回调事件
 	tim.Callback().Register(callback.EventAfterFriendDelete, func(ack callback.Ack, data interface{}) {
-		fmt.Printf("%+v", data.(callback.AfterFriendDelete))
+		fmt.Printf("%+v", data.(*callback.AfterFriendDelete))
 		_ = ack.AckSuccess(0)
 	})
 
 	// 开启监听
-	http.HandleFunc("/callback", func(writer http.ResponseWriter, request *http.Request) {
-		tim.Callback().Listen(writer, request)
-	})
+	http.Handle("/callback", tim.Callback())
 
 	// 启动服务器
 	if err := http.ListenAndServe(":8080", nil); err != nil {
@@ -1,3 +1,3 @@
 module github.com/d60-Lab/tencent-im
 
-go 1.21
+go 1.23
@@ -0,0 +1,121 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 群公告变更通知及历史记录
+ */
+
+package group
+
+import (
+	"time"
+)
+
+const (
+	AttrKeyNotificationHistory = "im_notification_history" // 保存历史群公告的群自定义属性
+
+	defaultNotificationHistoryLimit = 10 // 默认保留的历史群公告数量
+)
+
+type (
+	// AnnouncementOptions 群公告变更选项
+	AnnouncementOptions struct {
+		HistoryLimit int                                   // （选填）保留的历史群公告数量，默认10条
+		Silence      bool                                  // （选填）是否不发送群公告变更的系统通知
+		Content      func(previous, current string) string // （选填）系统通知的内容，默认为新的群公告
+		UserIds      []string                              // （选填）系统通知的接收者，不填表示全员下发
+		Now          func() time.Time                      // （选填）当前时间，默认 time.Now
+	}
+
+	// AnnouncementRecord 历史群公告
+	AnnouncementRecord struct {
+		Notification string `json:"notification"` // 群公告
+		ReplacedAt   int64  `json:"replaced_at"`  // 被替换的时间，UNIX 时间戳（单位：秒）
+	}
+)
+
+// UpdateGroupAnnouncement 修改群基础资料并记录群公告变更
+// 群公告发生变化时，在群内发送系统通知，并将此前的群公告记录到群自定义属性 AttrKeyNotificationHistory 中，供客户端展示历史公告。
+// 历史记录先于群资料写入，修改群资料失败时历史中可能多出一条仍在使用的公告，但不会丢失被替换的公告。
+// 历史记录的并发修改处理同 PinMessage，多个进程同时修改同一群组的公告时，调用方应按群串行调用。
+// 未设置群公告时等同于 UpdateGroup。返回群公告是否发生变化。
+func UpdateGroupAnnouncement(api API, group *Group, opt ...AnnouncementOptions) (changed bool, err error) {
+	if group.GetNotification() == "" {
+		err = api.UpdateGroup(group)
+		return
+	}
+
+	var o AnnouncementOptions
+	if len(opt) > 0 {
+		o = opt[0]
+	}
+	o.init()
+
+	filter := &Filter{}
+	filter.AddBaseInfoFilter(BaseFieldNotification)
+
+	current, err := api.GetGroup(group.GetGroupId(), filter)
+	if err != nil {
+		return
+	}
+
+	previous := current.GetNotification()
+	if changed = previous != group.GetNotification(); changed && previous != "" {
+		if err = recordAnnouncement(api, group.GetGroupId(), previous, o); err != nil {
+			return
+		}
+	}
+
+	if err = api.UpdateGroup(group); err != nil || !changed {
+		return
+	}
+
+	if !o.Silence {
+		err = api.SendNotification(group.GetGroupId(), o.Content(previous, group.GetNotification()), o.UserIds...)
+	}
+
+	return
+}
+
+// GetAnnouncementHistory 获取历史群公告，最近被替换的公告排在最前
+func GetAnnouncementHistory(api API, groupId string) (records []*AnnouncementRecord, err error) {
+	err = getAttrJSON(api, groupId, AttrKeyNotificationHistory, &records)
+	return
+}
+
+// 记录被替换的群公告
+func recordAnnouncement(api API, groupId, notification string, o AnnouncementOptions) (err error) {
+	record := &AnnouncementRecord{Notification: notification, ReplacedAt: o.Now().Unix()}
+
+	return updateAttrJSON(api, groupId, AttrKeyNotificationHistory, func(records []*AnnouncementRecord) (next []*AnnouncementRecord, changed bool, err error) {
+		for _, item := range records {
+			if *item == *record {
+				return
+			}
+		}
+
+		next = append([]*AnnouncementRecord{record}, records...)
+		if len(next) > o.HistoryLimit {
+			next = next[:o.HistoryLimit]
+		}
+
+		changed = true
+		return
+	})
+}
+
+// 初始化默认选项
+func (o *AnnouncementOptions) init() {
+	if o.HistoryLimit <= 0 {
+		o.HistoryLimit = defaultNotificationHistoryLimit
+	}
+
+	if o.Content == nil {
+		o.Content = func(previous, current string) string {
+			return current
+		}
+	}
+
+	if o.Now == nil {
+		o.Now = time.Now
+	}
+}
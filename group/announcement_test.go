@@ -0,0 +1,88 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 群公告变更通知及历史记录单元测试
+ */
+
+package group
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+// attrClient 以内存保存群自定义属性的测试客户端
+func attrClient(attrs map[string]string, handlers map[string]func(map[string]interface{}) string) *fakeClient {
+	handlers[commandGetGroupAttr] = func(req map[string]interface{}) string {
+		items := make([]map[string]interface{}, 0, len(attrs))
+		for key, val := range attrs {
+			items = append(items, map[string]interface{}{"Key": key, "Value": val})
+		}
+
+		b, _ := json.Marshal(map[string]interface{}{"ActionStatus": "OK", "Attrs": items})
+		return string(b)
+	}
+	handlers[commandModifyGroupAttr] = func(req map[string]interface{}) string {
+		for _, item := range req["Attrs"].([]interface{}) {
+			attr := item.(map[string]interface{})
+			attrs[attr["Key"].(string)] = attr["Value"].(string)
+		}
+		return `{"ActionStatus":"OK"}`
+	}
+
+	return newFakeClient(handlers)
+}
+
+func TestUpdateGroupAnnouncement(t *testing.T) {
+	attrs := map[string]string{AttrKeyNotificationHistory: `[{"notification":"v0","replaced_at":50}]`}
+	client := attrClient(attrs, map[string]func(map[string]interface{}) string{
+		commandGetGroups:                   reply(`{"ActionStatus":"OK","GroupInfo":[{"GroupId":"g1","Notification":"v1"}]}`),
+		commandUpdateGroup:                 reply(`{"ActionStatus":"OK"}`),
+		commandSendGroupSystemNotification: reply(`{"ActionStatus":"OK"}`),
+	})
+	a := NewAPI(client)
+
+	group := NewGroup("g1")
+	group.SetName("team")
+	group.SetNotification("v2")
+
+	changed, err := UpdateGroupAnnouncement(a, group, AnnouncementOptions{
+		HistoryLimit: 1,
+		Content: func(previous, current string) string {
+			return "公告已更新：" + current
+		},
+		Now: func() time.Time { return time.Unix(100, 0) },
+	})
+	if err != nil || !changed {
+		t.Fatalf("unexpected result: %v, %v", changed, err)
+	}
+
+	if body := client.last().Body; client.last().Command != commandSendGroupSystemNotification || body["Content"] != "公告已更新：v2" {
+		t.Fatalf("unexpected notification: %v", body)
+	}
+
+	var commands []string
+	for _, req := range client.requests {
+		if req.Command == commandModifyGroupAttr || req.Command == commandUpdateGroup {
+			commands = append(commands, req.Command)
+		}
+	}
+	if len(commands) != 2 || commands[0] != commandModifyGroupAttr || commands[1] != commandUpdateGroup {
+		t.Fatalf("history should be recorded before updating the group: %v", commands)
+	}
+
+	records, err := GetAnnouncementHistory(a, "g1")
+	if err != nil || len(records) != 1 || records[0].Notification != "v1" || records[0].ReplacedAt != 100 {
+		t.Fatalf("unexpected history: %+v, %v", records, err)
+	}
+
+	client.requests = nil
+	group.SetNotification("v1")
+	if changed, err = UpdateGroupAnnouncement(a, group); err != nil || changed {
+		t.Fatalf("unchanged notification should not be recorded: %v, %v", changed, err)
+	}
+	if n := len(client.requests); n != 2 {
+		t.Fatalf("expected only get and update requests, got %d", n)
+	}
+}
@@ -8,12 +8,16 @@
 package group
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"time"
 
 	"github.com/d60-Lab/tencent-im/internal/conv"
 	"github.com/d60-Lab/tencent-im/internal/core"
 	"github.com/d60-Lab/tencent-im/internal/enum"
 	"github.com/d60-Lab/tencent-im/internal/types"
+	"github.com/d60-Lab/tencent-im/pagination"
 )
 
 const (
@@ -48,17 +52,50 @@ const (
 	commandUpdateGroupCounter = "update_group_counter" // 更新群计数器
 	commandDeleteGroupCounter = "delete_group_counter" // 删除群计数器
 	commandModifyGroupAttr    = "modify_group_attr"    // 修改群自定义属性
+	commandGetGroupAttr       = "get_group_attr"       // 获取群自定义属性
 	commandClearGroupAttr     = "clear_group_attr"     // 清空群自定义属性
 	commandSetGroupAttr       = "set_group_attr"       // 重置群自定义属性
 	commandDeleteGroupAttr    = "delete_group_attr"    // 删除群自定义属性
 	commandGetGroupBanMember  = "get_group_ban_member" // 获取群封禁成员列表
 	commandBanGroupMember     = "ban_group_member"     // 封禁群成员
 	commandUnbanGroupMember   = "unban_group_member"   // 解封群成员
+	commandCreateTopic        = "create_topic"         // 创建话题
+	commandDestroyTopic       = "destroy_topic"        // 解散话题
+	commandGetTopic           = "get_topic"            // 获取话题资料
+	commandModifyTopic        = "modify_topic"         // 修改话题资料
+
+	commandSendBroadcastMsg = "send_broadcast_msg" // 直播群广播消息
+
+	serviceAVChatRoom       = "group_open_avchatroom_http_svc"
+	commandGetOnlineMembers = "get_members" // 获取直播群在线成员列表
+
+	commandGetGroupMsgReceipt       = "get_group_msg_receipt"        // 拉取群消息已读回执信息
+	commandGetGroupMsgReceiptDetail = "get_group_msg_receipt_detail" // 拉取群消息已读回执详情
 
 	serviceOpenIM         = "openim"
 	commandModifyGroupMsg = "modify_group_msg" // 修改历史群聊消息
 
-	batchGetGroupsLimit = 50 // 批量获取群组限制
+	serviceMsgExtension          = "openim_msg_ext_http_svc"
+	commandSetGroupMsgExtensions = "group_set_key_values" // 设置群消息扩展
+	commandGetGroupMsgExtensions = "group_get_key_values" // 拉取群消息扩展
+
+	batchGetGroupsLimit        = 50  // 批量获取群组限制
+	defaultReceiptMembersLimit = 100 // 默认单次拉取的已读回执成员数量
+
+	AttrKeyPinnedMessages = "im_pinned_messages" // 保存置顶消息的群自定义属性
+	maxPinnedMessages     = 10                   // 置顶消息数量上限
+	maxAttrUpdateAttempts = 3                    // 以 JSON 保存的群自定义属性被并发覆盖时的最大写入次数
+)
+
+var (
+	errCursorGroupMismatch = core.NewError(enum.InvalidParamsCode, "the cursor belongs to another group")
+	errNotSetBanMembers    = core.NewError(enum.InvalidParamsCode, "the members to ban is not set")
+	errInvalidBanDuration  = core.NewError(enum.InvalidParamsCode, "the ban duration must be greater than 0")
+	errNotSetMsgSeq        = core.NewError(enum.InvalidParamsCode, "the message seq is not set")
+	errTooManyPinned       = core.NewError(enum.InvalidParamsCode, fmt.Sprintf("the number of pinned messages cannot exceed %d", maxPinnedMessages))
+
+	// ErrAttrUpdateConflict 群自定义属性在多次重试后仍被并发写入覆盖
+	ErrAttrUpdateConflict = core.NewError(enum.InvalidResponseCode, "the group attribute was overwritten by a concurrent update")
 )
 
 type API interface {
@@ -80,6 +117,12 @@ type API interface {
 	// https://cloud.tencent.com/document/product/269/1614
 	PullGroups(arg *PullGroupsArg, fn func(ret *FetchGroupsRet)) (err error)
 
+	// IterGroups 迭代App中的所有群组
+	// 本方法由“拉取App中的所有群组（FetchGroups）”拓展而来，游标为下一页的群组ID分页标识，可传入此前保存的游标续拉
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/1614
+	IterGroups(arg *PullGroupsArg, cursor ...pagination.Cursor[int]) *pagination.Pager[*Group, int]
+
 	// CreateGroup 创建群组
 	// App 管理员可以通过该接口创建群组。
 	// 点击查看详细文档:
@@ -111,6 +154,12 @@ type API interface {
 	// https://cloud.tencent.com/document/product/269/1617
 	PullMembers(arg *PullMembersArg, fn func(ret *FetchMembersRet)) (err error)
 
+	// IterMembers 迭代群成员详细资料
+	// 本方法由“拉取群成员详细资料（FetchMembers）”拓展而来，游标为成员偏移量，可传入此前保存的游标续拉
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/1617
+	IterMembers(arg *PullMembersArg, cursor ...pagination.Cursor[int]) *pagination.Pager[*Member, int]
+
 	// UpdateGroup 修改群基础资料
 	// App管理员可以通过该接口修改指定群组的基础信息。
 	// 点击查看详细文档:
@@ -154,6 +203,12 @@ type API interface {
 	// https://cloud.tencent.com/document/product/269/1625
 	PullMemberGroups(arg *PullMemberGroupsArg, fn func(ret *FetchMemberGroupsRet)) (err error)
 
+	// IterMemberGroups 迭代用户所加入的群组
+	// 本方法由“拉取用户所加入的群组（FetchMemberGroups）”拓展而来，游标为群组偏移量，可传入此前保存的游标续拉
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/1625
+	IterMemberGroups(arg *PullMemberGroupsArg, cursor ...pagination.Cursor[int]) *pagination.Pager[*Group, int]
+
 	// GetRolesInGroup 查询用户在群组中的身份
 	// App管理员可以通过该接口获取一批用户在群内的身份，即“成员角色”。
 	// 点击查看详细文档:
@@ -262,6 +317,12 @@ type API interface {
 	// https://cloud.tencent.com/document/product/269/2738
 	PullMessages(groupId string, limit int, fn func(ret *FetchMessagesRet)) (err error)
 
+	// IterMessages 迭代群历史消息
+	// 本方法由“拉取群历史消息（FetchMessages）”拓展而来，按 Seq 从新到旧逐条迭代，游标记录群ID及待拉取的消息 Seq，可持久化后传入续拉
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/2738
+	IterMessages(groupId string, limit int, cursor ...pagination.Cursor[MessagesCursor]) *pagination.Pager[*Message, MessagesCursor]
+
 	// GetOnlineMemberNum 获取直播群在线人数
 	// App 管理员可以根据群组 ID 获取直播群在线人数。
 	// 点击查看详细文档:
@@ -335,6 +396,131 @@ type API interface {
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/74741
 	ModifyGroupMsg(groupId string, msgSeq int, message *Message) (err error)
+
+	// CreateTopic 创建话题
+	// App 管理员可以通过该接口在支持话题的社群中创建话题。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/78224
+	CreateTopic(groupId string, topic *Topic) (topicId string, err error)
+
+	// DestroyTopic 解散单个话题
+	// 本方法由“解散话题（DestroyTopics）”拓展而来
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/78223
+	DestroyTopic(groupId, topicId string) (err error)
+
+	// DestroyTopics 解散话题
+	// App 管理员可以通过该接口解散社群中的话题。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/78223
+	DestroyTopics(groupId string, topicIds ...string) (results map[string]int, err error)
+
+	// GetTopic 获取单个话题资料
+	// 本方法由“获取话题资料（GetTopics）”拓展而来
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/78222
+	GetTopic(groupId, topicId string) (topic *Topic, err error)
+
+	// GetTopics 获取话题资料
+	// App 管理员可以根据社群ID获取话题资料，不指定话题ID时获取全部话题。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/78222
+	GetTopics(groupId string, topicIds ...string) (topics []*Topic, err error)
+
+	// UpdateTopic 修改话题资料
+	// App 管理员可以通过该接口修改话题资料。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/78221
+	UpdateTopic(groupId string, topic *Topic) (err error)
+
+	// SendTopicMessage 在话题中发送普通消息
+	// 本方法由“在群组中发送普通消息（SendMessage）”拓展而来
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/1629
+	SendTopicMessage(groupId, topicId string, message *Message) (ret *SendMessageRet, err error)
+
+	// FetchTopicMessages 拉取话题历史消息
+	// 本方法由“拉取群历史消息（FetchMessages）”拓展而来
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/2738
+	FetchTopicMessages(groupId, topicId string, limit int, msgSeq ...int) (ret *FetchMessagesRet, err error)
+
+	// RevokeTopicMessage 撤回单条话题消息
+	// 本方法由“撤回多条群消息（RevokeMessages）”拓展而来
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/12341
+	RevokeTopicMessage(groupId, topicId string, msgSeq int) (err error)
+
+	// RevokeTopicMessages 撤回多条话题消息
+	// 本方法由“撤回多条群消息（RevokeMessages）”拓展而来
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/12341
+	RevokeTopicMessages(groupId, topicId string, msgSeq ...int) (results map[int]int, err error)
+
+	// SendBroadcastMessage 直播群广播消息
+	// App 管理员可以通过该接口向 App 中的所有直播群发送广播消息，广播消息的优先级高于直播群中的普通消息。
+	SendBroadcastMessage(message *Message) (msgSeq int, err error)
+
+	// FetchOnlineMembers 获取直播群在线成员列表
+	// App 管理员可以根据群组 ID 分页获取直播群的在线成员列表，首次拉取 timestamp 填0，后续填上次返回的 Next，Next 为0时表示已拉取完毕。
+	FetchOnlineMembers(groupId string, timestamp int64) (ret *FetchOnlineMembersRet, err error)
+
+	// IterOnlineMembers 迭代直播群在线成员
+	// 本方法由“获取直播群在线成员列表（FetchOnlineMembers）”拓展而来，游标为下一页的分页时间戳
+	IterOnlineMembers(groupId string, cursor ...pagination.Cursor[int64]) *pagination.Pager[string, int64]
+
+	// BanOnlineMembers 封禁直播群在线成员
+	// 本方法由“封禁群成员（BanGroupMember）”拓展而来，支持填写封禁原因
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/78860
+	BanOnlineMembers(arg *BanOnlineMembersArg) (err error)
+
+	// UnbanOnlineMembers 解封直播群成员
+	// 本方法由“解封群成员（UnbanGroupMember）”拓展而来
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/78861
+	UnbanOnlineMembers(groupId string, userIds ...string) (err error)
+
+	// GetMessageReceipt 拉取单条群消息已读回执信息
+	// 本方法由“拉取群消息已读回执信息（GetMessageReceipts）”拓展而来
+	GetMessageReceipt(groupId string, msgSeq int) (receipt *MessageReceipt, err error)
+
+	// GetMessageReceipts 拉取群消息已读回执信息
+	// App 管理员可以根据群ID及消息序列号批量拉取需要已读回执的群消息的已读、未读成员数。
+	GetMessageReceipts(groupId string, msgSeq ...int) (receipts map[int]*MessageReceipt, err error)
+
+	// FetchReceiptMembers 拉取群消息已读回执详情
+	// App 管理员可以分页拉取需要已读回执的群消息的已读或未读成员列表。
+	FetchReceiptMembers(arg *FetchReceiptMembersArg) (ret *FetchReceiptMembersRet, err error)
+
+	// IterReceiptMembers 迭代群消息已读回执成员
+	// 本方法由“拉取群消息已读回执详情（FetchReceiptMembers）”拓展而来，游标为下一页的分页游标
+	IterReceiptMembers(arg *FetchReceiptMembersArg, cursor ...pagination.Cursor[string]) *pagination.Pager[string, string]
+
+	// SetMessageExtensions 设置群消息扩展
+	// App 管理员可以为支持消息扩展的群消息设置扩展，返回服务端设置后的扩展及其最新 Seq。
+	// 扩展携带的 Seq 与服务端不一致时返回 *ExtensionConflictError，其中包含全部冲突扩展在服务端的当前值；
+	// 其他原因设置失败时返回包含全部失败扩展 Key 的错误（优先于冲突错误），results 仅包含设置成功的扩展。
+	SetMessageExtensions(groupId string, msgSeq int, extensions ...*MessageExtension) (results []*MessageExtension, err error)
+
+	// GetMessageExtensions 拉取群消息扩展
+	// App 管理员可以拉取支持消息扩展的群消息的全部扩展。
+	GetMessageExtensions(groupId string, msgSeq int) (extensions []*MessageExtension, err error)
+
+	// PinMessage 置顶群消息
+	// 本方法由“修改群自定义属性（ModifyGroupAttr）”拓展而来，置顶列表以 JSON 保存在群自定义属性 AttrKeyPinnedMessages 中，客户端可通过群属性获取。
+	// 最近置顶的消息排在最前，重复置顶同一消息将更新其置顶时间，最多置顶10条消息。
+	// 写入后会重新读取比较，被并发修改覆盖时基于最新列表重试，仍冲突时返回 ErrAttrUpdateConflict。
+	// 群自定义属性不支持条件写入，比较只能发现丢失的更新，多个进程同时修改同一群组的置顶列表时，调用方应按群串行调用。
+	PinMessage(groupId string, msgSeq int, operator ...string) (err error)
+
+	// UnpinMessage 取消置顶群消息
+	// 本方法由“修改群自定义属性（ModifyGroupAttr）”拓展而来，并发修改的处理同 PinMessage。
+	UnpinMessage(groupId string, msgSeq int) (err error)
+
+	// GetPinnedMessages 获取群置顶消息列表
+	// 本方法由“获取群自定义属性（GetGroupAttr）”拓展而来
+	GetPinnedMessages(groupId string) (pinned []*PinnedMessage, err error)
 }
 
 type api struct {
@@ -350,6 +536,11 @@ func NewAPI(client core.Client) API {
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/1614
 func (a *api) FetchGroupIds(limit int, next int, groupType ...Type) (ret *FetchGroupIdsRet, err error) {
+	return a.fetchGroupIds(context.Background(), limit, next, groupType...)
+}
+
+// fetchGroupIds 拉取App中的所有群组ID（上下文取消时中止请求）
+func (a *api) fetchGroupIds(ctx context.Context, limit int, next int, groupType ...Type) (ret *FetchGroupIdsRet, err error) {
 	req := &fetchGroupIdsReq{Limit: limit, Next: next}
 
 	if len(groupType) > 0 {
@@ -358,7 +549,7 @@ func (a *api) FetchGroupIds(limit int, next int, groupType ...Type) (ret *FetchG
 
 	resp := &fetchGroupIdsResp{}
 
-	if err = a.client.Post(serviceGroup, commandFetchGroupIds, req, resp); err != nil {
+	if err = a.client.PostContext(ctx, serviceGroup, commandFetchGroupIds, req, resp); err != nil {
 		return
 	}
 
@@ -380,6 +571,11 @@ func (a *api) FetchGroupIds(limit int, next int, groupType ...Type) (ret *FetchG
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/1614
 func (a *api) FetchGroups(limit int, next int, groupTypeAndFilter ...interface{}) (ret *FetchGroupsRet, err error) {
+	return a.fetchGroups(context.Background(), limit, next, groupTypeAndFilter...)
+}
+
+// fetchGroups 拉取App中的所有群组（上下文取消时中止请求）
+func (a *api) fetchGroups(ctx context.Context, limit int, next int, groupTypeAndFilter ...interface{}) (ret *FetchGroupsRet, err error) {
 	if limit > batchGetGroupsLimit {
 		err = core.NewError(enum.InvalidParamsCode, fmt.Sprintf("the number of groups id cannot exceed %d", batchGetGroupsLimit))
 		return
@@ -405,14 +601,14 @@ func (a *api) FetchGroups(limit int, next int, groupTypeAndFilter ...interface{}
 		}
 	}
 
-	if resp, err = a.FetchGroupIds(limit, next, groupType); err != nil {
+	if resp, err = a.fetchGroupIds(ctx, limit, next, groupType); err != nil {
 		return
 	}
 
 	ret = &FetchGroupsRet{Next: resp.Next, Total: resp.Total, HasMore: resp.HasMore}
 
 	if len(resp.List) > 0 {
-		if ret.List, err = a.GetGroups(resp.List, filter); err != nil {
+		if ret.List, err = a.getGroups(ctx, resp.List, filter); err != nil {
 			return
 		}
 	}
@@ -443,13 +639,29 @@ func (a *api) PullGroups(arg *PullGroupsArg, fn func(ret *FetchGroupsRet)) (err
 
 		if ret.HasMore {
 			next = ret.Next
-			break
 		}
 	}
 
 	return
 }
 
+// IterGroups 迭代App中的所有群组
+// 本方法由“拉取App中的所有群组（FetchGroups）”拓展而来，游标为下一页的群组ID分页标识，可传入此前保存的游标续拉
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/1614
+func (a *api) IterGroups(arg *PullGroupsArg, cursor ...pagination.Cursor[int]) *pagination.Pager[*Group, int] {
+	return pagination.New(func(ctx context.Context, next int) (page *pagination.Page[*Group, int], err error) {
+		ret, err := a.fetchGroups(ctx, arg.Limit, next, arg.Type, arg.Filter)
+		if err != nil {
+			return
+		}
+
+		page = &pagination.Page[*Group, int]{Items: ret.List, Next: ret.Next, HasMore: ret.HasMore}
+
+		return
+	}, cursor...)
+}
+
 // CreateGroup 创建群组
 // App管理员可以通过该接口创建群组。
 // 点击查看详细文档:
@@ -470,6 +682,10 @@ func (a *api) CreateGroup(group *Group) (groupId string, err error) {
 	req.MaxMemberNum = group.maxMemberNum
 	req.ApplyJoinOption = group.applyJoinOption
 
+	if group.supportTopic {
+		req.SupportTopic = 1
+	}
+
 	if data := group.GetAllCustomData(); data != nil {
 		req.AppDefinedData = make([]*customDataItem, 0, len(data))
 		for key, val := range data {
@@ -546,6 +762,11 @@ func (a *api) GetGroup(groupId string, filter ...*Filter) (group *Group, err err
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/1616
 func (a *api) GetGroups(groupIds []string, filters ...*Filter) (groups []*Group, err error) {
+	return a.getGroups(context.Background(), groupIds, filters...)
+}
+
+// getGroups 获取多个群详细资料（上下文取消时中止请求）
+func (a *api) getGroups(ctx context.Context, groupIds []string, filters ...*Filter) (groups []*Group, err error) {
 	if c := len(groupIds); c == 0 {
 		err = core.NewError(enum.InvalidParamsCode, "the group's id is not set")
 		return
@@ -568,7 +789,7 @@ func (a *api) GetGroups(groupIds []string, filters ...*Filter) (groups []*Group,
 		}
 	}
 
-	if err = a.client.Post(serviceGroup, commandGetGroups, req, resp); err != nil {
+	if err = a.client.PostContext(ctx, serviceGroup, commandGetGroups, req, resp); err != nil {
 		return
 	}
 
@@ -582,6 +803,8 @@ func (a *api) GetGroups(groupIds []string, filters ...*Filter) (groups []*Group,
 			group.groupType = item.Type
 			group.owner = item.OwnerUserId
 			group.avatar = item.FaceUrl
+			group.introduction = item.Introduction
+			group.notification = item.Notification
 			group.memberNum = item.MemberNum
 			group.maxMemberNum = item.MaxMemberNum
 			group.applyJoinOption = item.ApplyJoinOption
@@ -590,6 +813,7 @@ func (a *api) GetGroups(groupIds []string, filters ...*Filter) (groups []*Group,
 			group.lastMsgTime = item.LastMsgTime
 			group.shutUpStatus = item.ShutUpAllMember
 			group.nextMsgSeq = item.NextMsgSeq
+			group.supportTopic = item.SupportTopic == 1
 
 			if item.AppDefinedData != nil && len(item.AppDefinedData) > 0 {
 				for _, v := range item.AppDefinedData {
@@ -631,6 +855,11 @@ func (a *api) GetGroups(groupIds []string, filters ...*Filter) (groups []*Group,
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/1617
 func (a *api) FetchMembers(groupId string, limit, offset int, filters ...*Filter) (ret *FetchMembersRet, err error) {
+	return a.fetchMembers(context.Background(), groupId, limit, offset, filters...)
+}
+
+// fetchMembers 拉取群成员详细资料（上下文取消时中止请求）
+func (a *api) fetchMembers(ctx context.Context, groupId string, limit, offset int, filters ...*Filter) (ret *FetchMembersRet, err error) {
 	req := &fetchMembersReq{GroupId: groupId, Limit: limit, Offset: offset}
 
 	if len(filters) > 0 {
@@ -643,14 +872,14 @@ func (a *api) FetchMembers(groupId string, limit, offset int, filters ...*Filter
 
 	resp := &fetchMembersResp{}
 
-	if err = a.client.Post(serviceGroup, commandFetchGroupMembers, req, resp); err != nil {
+	if err = a.client.PostContext(ctx, serviceGroup, commandFetchGroupMembers, req, resp); err != nil {
 		return
 	}
 
 	ret = &FetchMembersRet{}
 	ret.Total = resp.MemberNum
 	ret.List = make([]*Member, 0, len(resp.MemberList))
-	ret.HasMore = resp.MemberNum > limit+offset
+	ret.HasMore = len(resp.MemberList) > 0 && resp.MemberNum > offset+len(resp.MemberList)
 
 	for _, m := range resp.MemberList {
 		member := &Member{
@@ -695,13 +924,34 @@ func (a *api) PullMembers(arg *PullMembersArg, fn func(ret *FetchMembersRet)) (e
 		fn(ret)
 
 		if ret.HasMore {
-			offset += arg.Limit
+			offset += len(ret.List)
 		}
 	}
 
 	return
 }
 
+// IterMembers 迭代群成员详细资料
+// 本方法由“拉取群成员详细资料（FetchMembers）”拓展而来，游标为成员偏移量，可传入此前保存的游标续拉
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/1617
+func (a *api) IterMembers(arg *PullMembersArg, cursor ...pagination.Cursor[int]) *pagination.Pager[*Member, int] {
+	return pagination.New(func(ctx context.Context, offset int) (page *pagination.Page[*Member, int], err error) {
+		ret, err := a.fetchMembers(ctx, arg.GroupId, arg.Limit, offset, arg.Filter)
+		if err != nil {
+			return
+		}
+
+		page = &pagination.Page[*Member, int]{
+			Items:   ret.List,
+			Next:    offset + len(ret.List),
+			HasMore: ret.HasMore,
+		}
+
+		return
+	}, cursor...)
+}
+
 // UpdateGroup 修改群基础资料
 // App管理员可以通过该接口修改指定群组的基础信息。
 // 点击查看详细文档:
@@ -852,6 +1102,11 @@ func (a *api) DestroyGroup(groupId string) (err error) {
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/1625
 func (a *api) FetchMemberGroups(arg *FetchMemberGroupsArg) (ret *FetchMemberGroupsRet, err error) {
+	return a.fetchMemberGroups(context.Background(), arg)
+}
+
+// fetchMemberGroups 拉取用户所加入的群组（上下文取消时中止请求）
+func (a *api) fetchMemberGroups(ctx context.Context, arg *FetchMemberGroupsArg) (ret *FetchMemberGroupsRet, err error) {
 	req := &fetchMemberGroupsReq{UserId: arg.UserId, Limit: arg.Limit, Offset: arg.Offset, Type: arg.Type}
 
 	if arg.Filter != nil {
@@ -871,7 +1126,7 @@ func (a *api) FetchMemberGroups(arg *FetchMemberGroupsArg) (ret *FetchMemberGrou
 
 	resp := &fetchMemberGroupsResp{}
 
-	if err = a.client.Post(serviceGroup, commandFetchMemberGroups, req, resp); err != nil {
+	if err = a.client.PostContext(ctx, serviceGroup, commandFetchMemberGroups, req, resp); err != nil {
 		return
 	}
 
@@ -882,7 +1137,7 @@ func (a *api) FetchMemberGroups(arg *FetchMemberGroupsArg) (ret *FetchMemberGrou
 	if arg.Limit == 0 {
 		ret.HasMore = false
 	} else {
-		ret.HasMore = arg.Limit+arg.Offset < resp.TotalCount
+		ret.HasMore = len(resp.GroupList) > 0 && arg.Offset+len(resp.GroupList) < resp.TotalCount
 	}
 
 	for _, item := range resp.GroupList {
@@ -900,6 +1155,7 @@ func (a *api) FetchMemberGroups(arg *FetchMemberGroupsArg) (ret *FetchMemberGrou
 		group.lastMsgTime = item.LastMsgTime
 		group.shutUpStatus = item.ShutUpAllMember
 		group.nextMsgSeq = item.NextMsgSeq
+		group.supportTopic = item.SupportTopic == 1
 
 		if item.AppDefinedData != nil && len(item.AppDefinedData) > 0 {
 			for _, v := range item.AppDefinedData {
@@ -960,13 +1216,42 @@ func (a *api) PullMemberGroups(arg *PullMemberGroupsArg, fn func(ret *FetchMembe
 		fn(ret)
 
 		if ret.HasMore {
-			req.Offset += arg.Limit
+			req.Offset += len(ret.List)
 		}
 	}
 
 	return
 }
 
+// IterMemberGroups 迭代用户所加入的群组
+// 本方法由“拉取用户所加入的群组（FetchMemberGroups）”拓展而来，游标为群组偏移量，可传入此前保存的游标续拉
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/1625
+func (a *api) IterMemberGroups(arg *PullMemberGroupsArg, cursor ...pagination.Cursor[int]) *pagination.Pager[*Group, int] {
+	return pagination.New(func(ctx context.Context, offset int) (page *pagination.Page[*Group, int], err error) {
+		ret, err := a.fetchMemberGroups(ctx, &FetchMemberGroupsArg{
+			UserId:               arg.UserId,
+			Limit:                arg.Limit,
+			Offset:               offset,
+			Type:                 arg.Type,
+			Filter:               arg.Filter,
+			IsWithNoActiveGroups: arg.IsWithNoActiveGroups,
+			IsWithLiveRoomGroups: arg.IsWithLiveRoomGroups,
+		})
+		if err != nil {
+			return
+		}
+
+		page = &pagination.Page[*Group, int]{
+			Items:   ret.List,
+			Next:    offset + len(ret.List),
+			HasMore: ret.HasMore,
+		}
+
+		return
+	}, cursor...)
+}
+
 // GetRolesInGroup 查询用户在群组中的身份
 // App管理员可以通过该接口获取一批用户在群内的身份，即“成员角色”。
 // 点击查看详细文档:
@@ -1040,12 +1325,18 @@ func (a *api) GetShuttedUpMembers(groupId string) (shuttedUps map[string]int64,
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/1629
 func (a *api) SendMessage(groupId string, message *Message) (ret *SendMessageRet, err error) {
+	return a.sendMessage(groupId, "", message)
+}
+
+// 在群组或话题中发送普通消息
+func (a *api) sendMessage(groupId, topicId string, message *Message) (ret *SendMessageRet, err error) {
 	if err = message.checkSendError(); err != nil {
 		return
 	}
 
 	req := &sendMessageReq{}
 	req.GroupId = groupId
+	req.TopicId = topicId
 	req.FromUserId = message.GetSender()
 	req.OfflinePushInfo = message.GetOfflinePushInfo()
 	req.MsgPriority = string(message.GetPriority())
@@ -1055,6 +1346,12 @@ func (a *api) SendMessage(groupId string, message *Message) (ret *SendMessageRet
 	req.SendMsgControl = message.GetSendMsgControl()
 	req.ForbidCallbackControl = message.GetForbidCallbackControl()
 	req.OnlineOnlyFlag = int(message.GetOnlineOnlyFlag())
+	if message.GetNeedReadReceipt() {
+		req.NeedReadReceipt = 1
+	}
+	if message.GetSupportMessageExtension() {
+		req.SupportExtension = 1
+	}
 
 	if message.atMembers != nil && len(message.atMembers) > 0 {
 		req.GroupAtInfo = make([]atInfo, 0, len(message.atMembers))
@@ -1122,9 +1419,14 @@ func (a *api) ChangeGroupOwner(groupId, userId string) (err error) {
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/12341
 func (a *api) RevokeMessage(groupId string, msgSeq int) (err error) {
+	return a.revokeMessage(groupId, "", msgSeq)
+}
+
+// 撤回单条群或话题消息
+func (a *api) revokeMessage(groupId, topicId string, msgSeq int) (err error) {
 	var results map[int]int
 
-	if results, err = a.RevokeMessages(groupId, msgSeq); err != nil {
+	if results, err = a.revokeMessages(groupId, topicId, msgSeq); err != nil {
 		return
 	}
 
@@ -1141,8 +1443,14 @@ func (a *api) RevokeMessage(groupId string, msgSeq int) (err error) {
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/12341
 func (a *api) RevokeMessages(groupId string, msgSeq ...int) (results map[int]int, err error) {
+	return a.revokeMessages(groupId, "", msgSeq...)
+}
+
+// 撤回多条群或话题消息
+func (a *api) revokeMessages(groupId, topicId string, msgSeq ...int) (results map[int]int, err error) {
 	req := revokeMessagesReq{}
 	req.GroupId = groupId
+	req.TopicId = topicId
 	req.MsgSeqList = make([]msgSeqItem, 0, len(msgSeq))
 	for _, seq := range msgSeq {
 		req.MsgSeqList = append(req.MsgSeqList, msgSeqItem{
@@ -1301,7 +1609,12 @@ func (a *api) RevokeMemberMessages(groupId, userId string) (err error) {
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/2738
 func (a *api) FetchMessages(groupId string, limit int, msgSeq ...int) (ret *FetchMessagesRet, err error) {
-	req := &fetchMessagesReq{GroupId: groupId, ReqMsgNumber: limit}
+	return a.fetchMessages(context.Background(), groupId, "", limit, msgSeq...)
+}
+
+// 拉取群或话题历史消息（上下文取消时中止请求）
+func (a *api) fetchMessages(ctx context.Context, groupId, topicId string, limit int, msgSeq ...int) (ret *FetchMessagesRet, err error) {
+	req := &fetchMessagesReq{GroupId: groupId, TopicId: topicId, ReqMsgNumber: limit}
 
 	if len(msgSeq) > 0 {
 		req.ReqMsgSeq = msgSeq[0]
@@ -1309,7 +1622,7 @@ func (a *api) FetchMessages(groupId string, limit int, msgSeq ...int) (ret *Fetc
 
 	resp := &fetchMessagesResp{}
 
-	if err = a.client.Post(serviceGroup, commandGetGroupSimpleMsg, req, resp); err != nil {
+	if err = a.client.PostContext(ctx, serviceGroup, commandGetGroupSimpleMsg, req, resp); err != nil {
 		return
 	}
 
@@ -1326,6 +1639,12 @@ func (a *api) FetchMessages(groupId string, limit int, msgSeq ...int) (ret *Fetc
 		if ret.IsFinished == 1 && count == limit {
 			ret.HasMore = true
 		}
+
+		if ret.NextSeq <= 0 {
+			ret.HasMore = false
+		}
+	} else {
+		ret.HasMore = false
 	}
 
 	ret.List = make([]*Message, 0, len(resp.RspMsgList))
@@ -1333,6 +1652,7 @@ func (a *api) FetchMessages(groupId string, limit int, msgSeq ...int) (ret *Fetc
 		message := NewMessage()
 		message.SetSender(item.FromUserId)
 		message.SetRandom(item.MsgRandom)
+		message.SetCustomData(item.CloudCustomData)
 		message.seq = item.MsgSeq
 		message.timestamp = item.MsgTimeStamp
 		message.status = MsgStatus(item.IsPlaceMsg)
@@ -1346,6 +1666,10 @@ func (a *api) FetchMessages(groupId string, limit int, msgSeq ...int) (ret *Fetc
 		case 4:
 			message.priority = MsgPriorityLowest
 		}
+		for i := range item.MsgBody {
+			message.AddBody(&item.MsgBody[i])
+		}
+		ret.List = append(ret.List, message)
 	}
 
 	return
@@ -1380,6 +1704,42 @@ func (a *api) PullMessages(groupId string, limit int, fn func(ret *FetchMessages
 	return
 }
 
+// IterMessages 迭代群历史消息
+// 本方法由“拉取群历史消息（FetchMessages）”拓展而来，按 Seq 从新到旧逐条迭代，游标记录群ID及待拉取的消息 Seq，可持久化后传入续拉
+// 拉取首页后游标即固定为首页最大的消息 Seq，保存的游标不会指向“最新消息”
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/2738
+func (a *api) IterMessages(groupId string, limit int, cursor ...pagination.Cursor[MessagesCursor]) *pagination.Pager[*Message, MessagesCursor] {
+	return pagination.New(func(ctx context.Context, c MessagesCursor) (page *pagination.Page[*Message, MessagesCursor], err error) {
+		if c.GroupId != "" && c.GroupId != groupId {
+			err = errCursorGroupMismatch
+			return
+		}
+
+		ret, err := a.fetchMessages(ctx, groupId, "", limit, c.MsgSeq)
+		if err != nil {
+			return
+		}
+
+		page = &pagination.Page[*Message, MessagesCursor]{
+			Items:   ret.List,
+			Next:    MessagesCursor{GroupId: groupId, MsgSeq: ret.NextSeq},
+			HasMore: ret.HasMore,
+		}
+
+		// 首页从最新消息开始拉取，将游标固定为本页最大的消息 Seq，避免新消息到达后续拉时偏移错位
+		if c.MsgSeq == 0 {
+			for _, message := range ret.List {
+				if message.seq > page.Pin.MsgSeq {
+					page.Pin = MessagesCursor{GroupId: groupId, MsgSeq: message.seq}
+				}
+			}
+		}
+
+		return
+	}, cursor...)
+}
+
 // GetOnlineMemberNum 获取直播群在线人数
 // App 管理员可以根据群组 ID 获取直播群在线人数。
 // 点击查看详细文档:
@@ -1504,7 +1864,7 @@ func (a *api) GetGroupAttr(groupId string, attrKeys ...string) (attrs map[string
 	}
 	resp := &getGroupAttrResp{}
 
-	if err = a.client.Post(serviceGroup, commandModifyGroupAttr, req, resp); err != nil {
+	if err = a.client.Post(serviceGroup, commandGetGroupAttr, req, resp); err != nil {
 		return
 	}
 
@@ -1535,17 +1895,10 @@ func (a *api) DeleteGroupAttr(groupId string, attrKeys ...string) (err error) {
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/78860
 func (a *api) BanGroupMember(groupId string, userIds []string, banSeconds int64) (err error) {
-	members := make([]banMemberItem, 0, len(userIds))
-	for _, userId := range userIds {
-		members = append(members, banMemberItem{
-			UserId:     userId,
-			BanSeconds: banSeconds,
-		})
-	}
-
 	req := &banGroupMemberReq{
-		GroupId: groupId,
-		Members: members,
+		GroupId:  groupId,
+		UserIds:  userIds,
+		Duration: banSeconds,
 	}
 	resp := &types.ActionBaseResp{}
 
@@ -1604,3 +1957,650 @@ func (a *api) ModifyGroupMsg(groupId string, msgSeq int, message *Message) (err
 	err = a.client.Post(serviceOpenIM, commandModifyGroupMsg, req, resp)
 	return
 }
+
+// CreateTopic 创建话题
+// App 管理员可以通过该接口在支持话题的社群中创建话题。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/78224
+func (a *api) CreateTopic(groupId string, topic *Topic) (topicId string, err error) {
+	if err = topic.checkCreateError(groupId); err != nil {
+		return
+	}
+
+	req := &createTopicReq{
+		GroupId:      groupId,
+		TopicId:      topic.id,
+		FromUserId:   topic.owner,
+		TopicName:    topic.name,
+		FaceUrl:      topic.avatar,
+		Introduction: topic.introduction,
+		Notification: topic.notification,
+		CustomString: topic.customString,
+	}
+	resp := &createTopicResp{}
+
+	if err = a.client.Post(serviceGroup, commandCreateTopic, req, resp); err != nil {
+		return
+	}
+
+	topicId = resp.TopicId
+
+	return
+}
+
+// DestroyTopic 解散单个话题
+// 本方法由“解散话题（DestroyTopics）”拓展而来
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/78223
+func (a *api) DestroyTopic(groupId, topicId string) (err error) {
+	var results map[string]int
+
+	if results, err = a.DestroyTopics(groupId, topicId); err != nil {
+		return
+	}
+
+	if code, ok := results[topicId]; ok && code != enum.SuccessCode {
+		err = core.NewError(code, "topic destroy failed")
+		return
+	}
+
+	return
+}
+
+// DestroyTopics 解散话题
+// App 管理员可以通过该接口解散社群中的话题。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/78223
+func (a *api) DestroyTopics(groupId string, topicIds ...string) (results map[string]int, err error) {
+	if len(topicIds) == 0 {
+		err = errNotSetTopicId
+		return
+	}
+
+	req := &destroyTopicReq{GroupId: groupId, TopicIdList: topicIds}
+	resp := &destroyTopicResp{}
+
+	if err = a.client.Post(serviceGroup, commandDestroyTopic, req, resp); err != nil {
+		return
+	}
+
+	results = make(map[string]int, len(resp.Results))
+	for _, item := range resp.Results {
+		results[item.TopicId] = item.ErrorCode
+	}
+
+	return
+}
+
+// GetTopic 获取单个话题资料
+// 本方法由“获取话题资料（GetTopics）”拓展而来
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/78222
+func (a *api) GetTopic(groupId, topicId string) (topic *Topic, err error) {
+	var topics []*Topic
+
+	if topicId == "" {
+		err = errNotSetTopicId
+		return
+	}
+
+	if topics, err = a.GetTopics(groupId, topicId); err != nil {
+		return
+	}
+
+	if len(topics) > 0 {
+		if err = topics[0].err; err != nil {
+			return
+		}
+
+		topic = topics[0]
+	}
+
+	return
+}
+
+// GetTopics 获取话题资料
+// App 管理员可以根据社群ID获取话题资料，不指定话题ID时获取全部话题。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/78222
+func (a *api) GetTopics(groupId string, topicIds ...string) (topics []*Topic, err error) {
+	req := &getTopicsReq{GroupId: groupId, TopicIdList: topicIds}
+	resp := &getTopicsResp{}
+
+	if err = a.client.Post(serviceGroup, commandGetTopic, req, resp); err != nil {
+		return
+	}
+
+	topics = make([]*Topic, 0, len(resp.TopicInfos))
+	for _, item := range resp.TopicInfos {
+		topic := NewTopic(item.TopicId)
+		topic.setError(item.ErrorCode, item.ErrorInfo)
+		if topic.err == nil {
+			topic.name = item.TopicName
+			topic.owner = item.OwnerUserId
+			topic.avatar = item.FaceUrl
+			topic.introduction = item.Introduction
+			topic.notification = item.Notification
+			topic.customString = item.CustomString
+			topic.shutUpStatus = item.MuteAllMember
+			topic.createTime = item.CreateTime
+			topic.lastMsgTime = item.LastMsgTime
+			topic.nextMsgSeq = item.NextMsgSeq
+		}
+
+		topics = append(topics, topic)
+	}
+
+	return
+}
+
+// UpdateTopic 修改话题资料
+// App 管理员可以通过该接口修改话题资料。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/78221
+func (a *api) UpdateTopic(groupId string, topic *Topic) (err error) {
+	if err = topic.checkUpdateError(); err != nil {
+		return
+	}
+
+	req := &updateTopicReq{
+		GroupId:       groupId,
+		TopicId:       topic.id,
+		TopicName:     topic.name,
+		FaceUrl:       topic.avatar,
+		Introduction:  topic.introduction,
+		Notification:  topic.notification,
+		MuteAllMember: topic.shutUpStatus,
+		CustomString:  topic.customString,
+	}
+
+	if err = a.client.Post(serviceGroup, commandModifyTopic, req, &types.ActionBaseResp{}); err != nil {
+		return
+	}
+
+	return
+}
+
+// SendTopicMessage 在话题中发送普通消息
+// 本方法由“在群组中发送普通消息（SendMessage）”拓展而来
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/1629
+func (a *api) SendTopicMessage(groupId, topicId string, message *Message) (ret *SendMessageRet, err error) {
+	if topicId == "" {
+		err = errNotSetTopicId
+		return
+	}
+
+	return a.sendMessage(groupId, topicId, message)
+}
+
+// FetchTopicMessages 拉取话题历史消息
+// 本方法由“拉取群历史消息（FetchMessages）”拓展而来
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/2738
+func (a *api) FetchTopicMessages(groupId, topicId string, limit int, msgSeq ...int) (ret *FetchMessagesRet, err error) {
+	if topicId == "" {
+		err = errNotSetTopicId
+		return
+	}
+
+	return a.fetchMessages(context.Background(), groupId, topicId, limit, msgSeq...)
+}
+
+// RevokeTopicMessage 撤回单条话题消息
+// 本方法由“撤回多条群消息（RevokeMessages）”拓展而来
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/12341
+func (a *api) RevokeTopicMessage(groupId, topicId string, msgSeq int) (err error) {
+	if topicId == "" {
+		err = errNotSetTopicId
+		return
+	}
+
+	return a.revokeMessage(groupId, topicId, msgSeq)
+}
+
+// RevokeTopicMessages 撤回多条话题消息
+// 本方法由“撤回多条群消息（RevokeMessages）”拓展而来
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/12341
+func (a *api) RevokeTopicMessages(groupId, topicId string, msgSeq ...int) (results map[int]int, err error) {
+	if topicId == "" {
+		err = errNotSetTopicId
+		return
+	}
+
+	return a.revokeMessages(groupId, topicId, msgSeq...)
+}
+
+// SendBroadcastMessage 直播群广播消息
+// App 管理员可以通过该接口向 App 中的所有直播群发送广播消息，广播消息的优先级高于直播群中的普通消息。
+func (a *api) SendBroadcastMessage(message *Message) (msgSeq int, err error) {
+	if err = message.checkSendError(); err != nil {
+		return
+	}
+
+	req := &sendBroadcastMessageReq{}
+	req.FromUserId = message.GetSender()
+	req.Random = message.GetRandom()
+	req.MsgBody = message.GetBody()
+	req.CloudCustomData = conv.String(message.GetCustomData())
+	resp := &sendBroadcastMessageResp{}
+
+	if err = a.client.Post(serviceGroup, commandSendBroadcastMsg, req, resp); err != nil {
+		return
+	}
+
+	msgSeq = resp.MsgSeq
+
+	return
+}
+
+// FetchOnlineMembers 获取直播群在线成员列表
+// App 管理员可以根据群组 ID 分页获取直播群的在线成员列表，首次拉取 timestamp 填0，后续填上次返回的 Next，Next 为0时表示已拉取完毕。
+func (a *api) FetchOnlineMembers(groupId string, timestamp int64) (ret *FetchOnlineMembersRet, err error) {
+	return a.fetchOnlineMembers(context.Background(), groupId, timestamp)
+}
+
+// fetchOnlineMembers 获取直播群在线成员列表（上下文取消时中止请求）
+func (a *api) fetchOnlineMembers(ctx context.Context, groupId string, timestamp int64) (ret *FetchOnlineMembersRet, err error) {
+	req := &fetchOnlineMembersReq{GroupId: groupId, Timestamp: timestamp}
+	resp := &fetchOnlineMembersResp{}
+
+	if err = a.client.PostContext(ctx, serviceAVChatRoom, commandGetOnlineMembers, req, resp); err != nil {
+		return
+	}
+
+	ret = &FetchOnlineMembersRet{}
+	ret.Next = resp.NextTimestamp
+	ret.HasMore = resp.NextTimestamp != 0
+	ret.List = make([]string, 0, len(resp.MemberList))
+	for _, member := range resp.MemberList {
+		ret.List = append(ret.List, member.UserId)
+	}
+
+	return
+}
+
+// IterOnlineMembers 迭代直播群在线成员
+// 本方法由“获取直播群在线成员列表（FetchOnlineMembers）”拓展而来，游标为下一页的分页时间戳
+func (a *api) IterOnlineMembers(groupId string, cursor ...pagination.Cursor[int64]) *pagination.Pager[string, int64] {
+	return pagination.New(func(ctx context.Context, timestamp int64) (page *pagination.Page[string, int64], err error) {
+		ret, err := a.fetchOnlineMembers(ctx, groupId, timestamp)
+		if err != nil {
+			return
+		}
+
+		page = &pagination.Page[string, int64]{Items: ret.List, Next: ret.Next, HasMore: ret.HasMore}
+
+		return
+	}, cursor...)
+}
+
+// BanOnlineMembers 封禁直播群在线成员
+// 本方法由“封禁群成员（BanGroupMember）”拓展而来，支持填写封禁原因
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/78860
+func (a *api) BanOnlineMembers(arg *BanOnlineMembersArg) (err error) {
+	if len(arg.UserIds) == 0 {
+		err = errNotSetBanMembers
+		return
+	}
+
+	if arg.Duration <= 0 {
+		err = errInvalidBanDuration
+		return
+	}
+
+	req := &banGroupMemberReq{
+		GroupId:     arg.GroupId,
+		UserIds:     arg.UserIds,
+		Duration:    arg.Duration,
+		Description: arg.Description,
+	}
+
+	err = a.client.Post(serviceGroup, commandBanGroupMember, req, &types.ActionBaseResp{})
+
+	return
+}
+
+// UnbanOnlineMembers 解封直播群成员
+// 本方法由“解封群成员（UnbanGroupMember）”拓展而来
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/78861
+func (a *api) UnbanOnlineMembers(groupId string, userIds ...string) (err error) {
+	if len(userIds) == 0 {
+		err = errNotSetBanMembers
+		return
+	}
+
+	return a.UnbanGroupMember(groupId, userIds)
+}
+
+// GetMessageReceipt 拉取单条群消息已读回执信息
+// 本方法由“拉取群消息已读回执信息（GetMessageReceipts）”拓展而来
+func (a *api) GetMessageReceipt(groupId string, msgSeq int) (receipt *MessageReceipt, err error) {
+	receipts, err := a.GetMessageReceipts(groupId, msgSeq)
+	if err != nil {
+		return
+	}
+
+	receipt = receipts[msgSeq]
+
+	return
+}
+
+// GetMessageReceipts 拉取群消息已读回执信息
+// App 管理员可以根据群ID及消息序列号批量拉取需要已读回执的群消息的已读、未读成员数。
+func (a *api) GetMessageReceipts(groupId string, msgSeq ...int) (receipts map[int]*MessageReceipt, err error) {
+	if len(msgSeq) == 0 {
+		err = errNotSetMsgSeq
+		return
+	}
+
+	req := &getMessageReceiptsReq{GroupId: groupId, MsgSeqList: make([]msgSeqItem, 0, len(msgSeq))}
+	resp := &getMessageReceiptsResp{}
+
+	for _, seq := range msgSeq {
+		req.MsgSeqList = append(req.MsgSeqList, msgSeqItem{
+			MsgSeq: seq,
+		})
+	}
+
+	if err = a.client.Post(serviceGroup, commandGetGroupMsgReceipt, req, resp); err != nil {
+		return
+	}
+
+	receipts = make(map[int]*MessageReceipt, len(resp.ReadReceiptList))
+	for _, item := range resp.ReadReceiptList {
+		receipts[item.MsgSeq] = &MessageReceipt{
+			MsgSeq:    item.MsgSeq,
+			ReadNum:   item.ReadNum,
+			UnreadNum: item.UnreadNum,
+		}
+	}
+
+	return
+}
+
+// FetchReceiptMembers 拉取群消息已读回执详情
+// App 管理员可以分页拉取需要已读回执的群消息的已读或未读成员列表。
+func (a *api) FetchReceiptMembers(arg *FetchReceiptMembersArg) (ret *FetchReceiptMembersRet, err error) {
+	return a.fetchReceiptMembers(context.Background(), arg)
+}
+
+// fetchReceiptMembers 拉取群消息已读回执详情（上下文取消时中止请求）
+func (a *api) fetchReceiptMembers(ctx context.Context, arg *FetchReceiptMembersArg) (ret *FetchReceiptMembersRet, err error) {
+	if arg.MsgSeq <= 0 {
+		err = errNotSetMsgSeq
+		return
+	}
+
+	req := &fetchReceiptMembersReq{
+		GroupId: arg.GroupId,
+		MsgSeq:  arg.MsgSeq,
+		Num:     arg.Limit,
+		Cursor:  arg.Cursor,
+		Flag:    int(arg.Flag),
+	}
+	resp := &fetchReceiptMembersResp{}
+
+	if req.Num <= 0 {
+		req.Num = defaultReceiptMembersLimit
+	}
+
+	if err = a.client.PostContext(ctx, serviceGroup, commandGetGroupMsgReceiptDetail, req, resp); err != nil {
+		return
+	}
+
+	members := resp.ReadList
+	if arg.Flag == ReceiptFlagUnread {
+		members = resp.UnreadList
+	}
+
+	ret = &FetchReceiptMembersRet{}
+	ret.Cursor = resp.Cursor
+	ret.HasMore = resp.IsFinish == 0 && resp.Cursor != ""
+	ret.List = make([]string, 0, len(members))
+	for _, member := range members {
+		ret.List = append(ret.List, member.UserId)
+	}
+
+	return
+}
+
+// IterReceiptMembers 迭代群消息已读回执成员
+// 本方法由“拉取群消息已读回执详情（FetchReceiptMembers）”拓展而来，游标为下一页的分页游标
+func (a *api) IterReceiptMembers(arg *FetchReceiptMembersArg, cursor ...pagination.Cursor[string]) *pagination.Pager[string, string] {
+	return pagination.New(func(ctx context.Context, next string) (page *pagination.Page[string, string], err error) {
+		ret, err := a.fetchReceiptMembers(ctx, &FetchReceiptMembersArg{
+			GroupId: arg.GroupId,
+			MsgSeq:  arg.MsgSeq,
+			Flag:    arg.Flag,
+			Limit:   arg.Limit,
+			Cursor:  next,
+		})
+		if err != nil {
+			return
+		}
+
+		page = &pagination.Page[string, string]{Items: ret.List, Next: ret.Cursor, HasMore: ret.HasMore}
+
+		return
+	}, cursor...)
+}
+
+// SetMessageExtensions 设置群消息扩展
+// App 管理员可以为支持消息扩展的群消息设置扩展，返回服务端设置后的扩展及其最新 Seq。
+// 扩展携带的 Seq 与服务端不一致时返回 *ExtensionConflictError，其中包含全部冲突扩展在服务端的当前值；
+// 其他原因设置失败时返回包含全部失败扩展 Key 的错误（优先于冲突错误），results 仅包含设置成功的扩展。
+func (a *api) SetMessageExtensions(groupId string, msgSeq int, extensions ...*MessageExtension) (results []*MessageExtension, err error) {
+	if err = checkExtensionsSetError(extensions); err != nil {
+		return
+	}
+
+	req := &setMessageExtensionsReq{GroupId: groupId, MsgSeq: msgSeq, ExtensionList: make([]extensionItem, 0, len(extensions))}
+	resp := &setMessageExtensionsResp{}
+
+	for _, extension := range extensions {
+		req.ExtensionList = append(req.ExtensionList, extensionItem{
+			Key:   extension.Key,
+			Value: extension.Value,
+			Seq:   extension.Seq,
+		})
+	}
+
+	if err = a.client.Post(serviceMsgExtension, commandSetGroupMsgExtensions, req, resp); err != nil {
+		return
+	}
+
+	sent := make(map[string]int64, len(extensions))
+	for _, extension := range extensions {
+		sent[extension.Key] = extension.Seq
+	}
+
+	var (
+		conflict *ExtensionConflictError
+		failed   []string
+		code     int
+	)
+
+	results = make([]*MessageExtension, 0, len(resp.ResultList))
+	for _, item := range resp.ResultList {
+		extension := &MessageExtension{Key: item.Extension.Key, Value: item.Extension.Value, Seq: item.Extension.Seq}
+
+		switch {
+		case item.ErrorCode == enum.SuccessCode:
+			results = append(results, extension)
+		case item.Extension.Key != "" && item.Extension.Seq != sent[item.Extension.Key]:
+			// 服务端返回的当前 Seq 与请求携带的不一致，扩展已被其他请求修改
+			if conflict == nil {
+				conflict = &ExtensionConflictError{GroupId: groupId, MsgSeq: msgSeq, ErrorCode: item.ErrorCode}
+			}
+			conflict.Conflicts = append(conflict.Conflicts, extension)
+		default:
+			if len(failed) == 0 {
+				code = item.ErrorCode
+			}
+			failed = append(failed, item.Extension.Key)
+		}
+	}
+
+	if len(failed) > 0 {
+		err = core.NewError(code, fmt.Sprintf("message extension set failed, keys: %v", failed))
+	} else if conflict != nil {
+		err = conflict
+	}
+
+	return
+}
+
+// GetMessageExtensions 拉取群消息扩展
+// App 管理员可以拉取支持消息扩展的群消息的全部扩展。
+func (a *api) GetMessageExtensions(groupId string, msgSeq int) (extensions []*MessageExtension, err error) {
+	req := &getMessageExtensionsReq{GroupId: groupId, MsgSeq: msgSeq, StartSeq: 1}
+
+	for {
+		resp := &getMessageExtensionsResp{}
+
+		if err = a.client.Post(serviceMsgExtension, commandGetGroupMsgExtensions, req, resp); err != nil {
+			return
+		}
+
+		startSeq := req.StartSeq
+		for _, item := range resp.ExtensionList {
+			if item.Seq < startSeq {
+				continue
+			}
+
+			extensions = append(extensions, &MessageExtension{Key: item.Key, Value: item.Value, Seq: item.Seq})
+			if item.Seq >= req.StartSeq {
+				req.StartSeq = item.Seq + 1
+			}
+		}
+
+		if resp.Complete == 1 || len(resp.ExtensionList) == 0 {
+			return
+		}
+
+		// 未完成拉取但本页未推进 StartSeq，继续请求将重复拉取同一页
+		if req.StartSeq == startSeq {
+			err = pagination.ErrStalled
+			return
+		}
+	}
+}
+
+// PinMessage 置顶群消息
+// 本方法由“修改群自定义属性（ModifyGroupAttr）”拓展而来，置顶列表以 JSON 保存在群自定义属性 AttrKeyPinnedMessages 中，客户端可通过群属性获取。
+// 最近置顶的消息排在最前，重复置顶同一消息将更新其置顶时间，最多置顶10条消息。
+// 写入后会重新读取比较，被并发修改覆盖时基于最新列表重试，仍冲突时返回 ErrAttrUpdateConflict。
+// 群自定义属性不支持条件写入，比较只能发现丢失的更新，多个进程同时修改同一群组的置顶列表时，调用方应按群串行调用。
+func (a *api) PinMessage(groupId string, msgSeq int, operator ...string) (err error) {
+	if msgSeq <= 0 {
+		err = errNotSetMsgSeq
+		return
+	}
+
+	message := &PinnedMessage{MsgSeq: msgSeq, PinTime: time.Now().Unix()}
+	if len(operator) > 0 {
+		message.Operator = operator[0]
+	}
+
+	return updateAttrJSON(a, groupId, AttrKeyPinnedMessages, func(pinned []*PinnedMessage) (list []*PinnedMessage, changed bool, err error) {
+		if len(pinned) > 0 && *pinned[0] == *message {
+			return
+		}
+
+		list = []*PinnedMessage{message}
+		for _, item := range pinned {
+			if item.MsgSeq != msgSeq {
+				list = append(list, item)
+			}
+		}
+
+		if len(list) > maxPinnedMessages {
+			err = errTooManyPinned
+			return
+		}
+
+		changed = true
+		return
+	})
+}
+
+// UnpinMessage 取消置顶群消息
+// 本方法由“修改群自定义属性（ModifyGroupAttr）”拓展而来，并发修改的处理同 PinMessage。
+func (a *api) UnpinMessage(groupId string, msgSeq int) (err error) {
+	return updateAttrJSON(a, groupId, AttrKeyPinnedMessages, func(pinned []*PinnedMessage) (list []*PinnedMessage, changed bool, err error) {
+		list = make([]*PinnedMessage, 0, len(pinned))
+		for _, item := range pinned {
+			if item.MsgSeq != msgSeq {
+				list = append(list, item)
+			}
+		}
+
+		changed = len(list) != len(pinned)
+		return
+	})
+}
+
+// GetPinnedMessages 获取群置顶消息列表
+// 本方法由“获取群自定义属性（GetGroupAttr）”拓展而来
+func (a *api) GetPinnedMessages(groupId string) (pinned []*PinnedMessage, err error) {
+	err = getAttrJSON(a, groupId, AttrKeyPinnedMessages, &pinned)
+	return
+}
+
+// 获取以 JSON 保存的群自定义属性，属性不存在时保持 v 不变
+func getAttrJSON(api API, groupId, key string, v interface{}) (err error) {
+	attrs, err := api.GetGroupAttr(groupId, key)
+	if err != nil {
+		return
+	}
+
+	if val, ok := attrs[key].(string); ok && val != "" {
+		err = json.Unmarshal([]byte(val), v)
+	}
+
+	return
+}
+
+// 以 JSON 保存群自定义属性
+func setAttrJSON(api API, groupId, key string, v interface{}) (err error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return
+	}
+
+	return api.ModifyGroupAttr(groupId, map[string]interface{}{key: string(b)})
+}
+
+// 以读取-修改-写入的方式更新以 JSON 保存的群自定义属性
+// modify 基于当前值返回新值，当前值已包含本次修改时应返回 changed 为 false。
+// 每次写入后重新读取并调用 modify 比较，修改已生效时返回；被并发写入覆盖时基于最新值重新写入，写入超过 maxAttrUpdateAttempts 次仍未生效时返回 ErrAttrUpdateConflict。
+func updateAttrJSON[T any](api API, groupId, key string, modify func(current T) (next T, changed bool, err error)) (err error) {
+	for attempt := 0; ; attempt++ {
+		var (
+			current, next T
+			changed       bool
+		)
+
+		if err = getAttrJSON(api, groupId, key, &current); err != nil {
+			return
+		}
+
+		if next, changed, err = modify(current); err != nil || !changed {
+			return
+		}
+
+		if attempt >= maxAttrUpdateAttempts {
+			err = ErrAttrUpdateConflict
+			return
+		}
+
+		if err = setAttrJSON(api, groupId, key, next); err != nil {
+			return
+		}
+	}
+}
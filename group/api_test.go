@@ -0,0 +1,635 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 群组接口单元测试
+ */
+
+package group
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/d60-Lab/tencent-im/internal/core"
+	"github.com/d60-Lab/tencent-im/internal/enum"
+	"github.com/d60-Lab/tencent-im/internal/types"
+	"github.com/d60-Lab/tencent-im/pagination"
+)
+
+type (
+	// fakeClient 按命令应答的测试客户端
+	fakeClient struct {
+		core.Client
+		handlers map[string]func(req map[string]interface{}) string
+		requests []*fakeRequest
+	}
+
+	// fakeRequest 测试客户端收到的请求
+	fakeRequest struct {
+		Command string
+		Body    map[string]interface{}
+		Context context.Context
+	}
+)
+
+func newFakeClient(handlers map[string]func(req map[string]interface{}) string) *fakeClient {
+	return &fakeClient{handlers: handlers}
+}
+
+// Post 以命令对应的处理函数应答请求
+func (c *fakeClient) Post(serviceName string, command string, data interface{}, resp interface{}) error {
+	return c.PostContext(context.Background(), serviceName, command, data, resp)
+}
+
+// PostContext 以命令对应的处理函数应答请求，上下文已取消时直接返回
+func (c *fakeClient) PostContext(ctx context.Context, serviceName string, command string, data interface{}, resp interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	req := make(map[string]interface{})
+	if err = json.Unmarshal(b, &req); err != nil {
+		return err
+	}
+	c.requests = append(c.requests, &fakeRequest{Command: command, Body: req, Context: ctx})
+
+	handler, ok := c.handlers[command]
+	if !ok {
+		return errors.New("unexpected command " + command)
+	}
+
+	if err = json.Unmarshal([]byte(handler(req)), resp); err != nil {
+		return err
+	}
+
+	// 与真实客户端一致，业务错误以 core.Error 返回
+	if r, ok := resp.(types.ActionBaseRespInterface); ok && r.GetErrorCode() != enum.SuccessCode {
+		return core.NewError(r.GetErrorCode(), r.GetErrorInfo())
+	}
+
+	return nil
+}
+
+// last 获取最后一次请求
+func (c *fakeClient) last() *fakeRequest {
+	if len(c.requests) == 0 {
+		return nil
+	}
+
+	return c.requests[len(c.requests)-1]
+}
+
+func reply(body string) func(map[string]interface{}) string {
+	return func(map[string]interface{}) string { return body }
+}
+
+func TestAPI_CreateCommunity(t *testing.T) {
+	client := newFakeClient(map[string]func(map[string]interface{}) string{
+		commandCreateGroup: reply(`{"ActionStatus":"OK","GroupId":"@TGS#_abc"}`),
+	})
+	a := NewAPI(client)
+
+	g := NewGroup("@TGS#_abc")
+	g.SetGroupType(TypeCommunity)
+	g.SetName("community")
+	g.SetSupportTopic(true)
+
+	groupId, err := a.CreateGroup(g)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if groupId != "@TGS#_abc" || client.last().Body["SupportTopic"] != float64(1) || client.last().Body["Type"] != "Community" {
+		t.Fatalf("unexpected request: %v", client.last().Body)
+	}
+
+	g = NewGroup("custom")
+	g.SetGroupType(TypeCommunity)
+	g.SetName("community")
+	if _, err = a.CreateGroup(g); err != errInvalidCommunityGroupId {
+		t.Fatalf("expected invalid community group id error, got %v", err)
+	}
+
+	g = NewGroup()
+	g.SetGroupType(TypePublic)
+	g.SetName("public")
+	g.SetSupportTopic(true)
+	if _, err = a.CreateGroup(g); err != errTopicNotSupported {
+		t.Fatalf("expected topic not supported error, got %v", err)
+	}
+}
+
+func TestAPI_Topic(t *testing.T) {
+	client := newFakeClient(map[string]func(map[string]interface{}) string{
+		commandCreateTopic: func(req map[string]interface{}) string {
+			return `{"ActionStatus":"OK","TopicId":"` + req["TopicId"].(string) + `"}`
+		},
+		commandGetTopic: reply(`{"ActionStatus":"OK","TopicInfo":[
+			{"TopicId":"@TGS#_abc@TOPIC#_news","TopicName":"news","MuteAllMember":"Off","NextMsgSeq":8},
+			{"TopicId":"@TGS#_abc@TOPIC#_gone","ErrorCode":10010,"ErrorInfo":"topic not exist"}
+		]}`),
+		commandDestroyTopic:      reply(`{"ActionStatus":"OK","DestroyResultItem":[{"TopicId":"@TGS#_abc@TOPIC#_news","ErrorCode":10004}]}`),
+		commandSendGroupMsg:      reply(`{"ActionStatus":"OK","MsgSeq":9,"MsgTime":1}`),
+		commandRecallGroupMsg:    reply(`{"ActionStatus":"OK","Results":[{"MsgSeq":9,"RetCode":0}]}`),
+		commandGetGroupSimpleMsg: reply(`{"ActionStatus":"OK","IsFinished":1,"RspMsgList":[{"MsgSeq":9}]}`),
+	})
+	a := NewAPI(client)
+
+	topic := NewTopic(TopicId("@TGS#_abc", "news"))
+	topic.SetName("news")
+	topicId, err := a.CreateTopic("@TGS#_abc", topic)
+	if err != nil || topicId != "@TGS#_abc@TOPIC#_news" {
+		t.Fatalf("unexpected create result: %s, %v", topicId, err)
+	}
+
+	if _, err = a.CreateTopic("@TGS#_other", topic); err != errInvalidTopicId {
+		t.Fatalf("expected invalid topic id error, got %v", err)
+	}
+
+	topics, err := a.GetTopics("@TGS#_abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(topics) != 2 || topics[0].GetName() != "news" || topics[0].GetNextMsgSeq() != 8 || topics[1].IsValid() {
+		t.Fatalf("unexpected topics: %+v", topics)
+	}
+	if _, ok := client.last().Body["TopicIdList"]; ok {
+		t.Fatalf("topic id list should be omitted: %v", client.last().Body)
+	}
+
+	var e core.Error
+	if err = a.DestroyTopic("@TGS#_abc", "@TGS#_abc@TOPIC#_news"); !errors.As(err, &e) || e.Code() != 10004 {
+		t.Fatalf("expected destroy error 10004, got %v", err)
+	}
+
+	message := NewMessage()
+	message.AddContent(&types.MsgTextContent{Text: "hi"})
+	if _, err = a.SendTopicMessage("@TGS#_abc", "", message); err != errNotSetTopicId {
+		t.Fatalf("expected topic id error, got %v", err)
+	}
+
+	for _, call := range []func() error{
+		func() error {
+			_, err := a.SendTopicMessage("@TGS#_abc", "@TGS#_abc@TOPIC#_news", message)
+			return err
+		},
+		func() error { return a.RevokeTopicMessage("@TGS#_abc", "@TGS#_abc@TOPIC#_news", 9) },
+		func() error {
+			_, err := a.FetchTopicMessages("@TGS#_abc", "@TGS#_abc@TOPIC#_news", 20)
+			return err
+		},
+	} {
+		if err = call(); err != nil {
+			t.Fatal(err)
+		}
+		if client.last().Body["TopicId"] != "@TGS#_abc@TOPIC#_news" {
+			t.Fatalf("topic id not set: %v", client.last().Body)
+		}
+	}
+}
+
+func TestAPI_IterGroups(t *testing.T) {
+	client := newFakeClient(map[string]func(map[string]interface{}) string{
+		commandFetchGroupIds: func(req map[string]interface{}) string {
+			switch req["Next"] {
+			case nil:
+				return `{"ActionStatus":"OK","Next":2,"TotalCount":3,"GroupIdList":[{"GroupId":"g1"}]}`
+			case float64(2):
+				return `{"ActionStatus":"OK","Next":3,"TotalCount":3,"GroupIdList":[{"GroupId":"g2"}]}`
+			}
+			return `{"ActionStatus":"OK","Next":0,"TotalCount":3,"GroupIdList":[{"GroupId":"g3"}]}`
+		},
+		commandGetGroups: func(req map[string]interface{}) string {
+			b, _ := json.Marshal(req["GroupIdList"])
+			var ids []string
+			_ = json.Unmarshal(b, &ids)
+
+			infos := make([]map[string]interface{}, 0, len(ids))
+			for _, id := range ids {
+				infos = append(infos, map[string]interface{}{"GroupId": id})
+			}
+			b, _ = json.Marshal(map[string]interface{}{"ActionStatus": "OK", "GroupInfo": infos})
+			return string(b)
+		},
+	})
+	a := NewAPI(client)
+
+	var pulled []string
+	if err := a.PullGroups(&PullGroupsArg{Limit: 1}, func(ret *FetchGroupsRet) {
+		for _, g := range ret.List {
+			pulled = append(pulled, g.GetGroupId())
+		}
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	type ctxKey struct{}
+	ctx := context.WithValue(context.Background(), ctxKey{}, "iter")
+	client.requests = nil
+
+	var iterated []string
+	for g, err := range a.IterGroups(&PullGroupsArg{Limit: 1}).All(ctx) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		iterated = append(iterated, g.GetGroupId())
+	}
+
+	want := []string{"g1", "g2", "g3"}
+	if !reflect.DeepEqual(pulled, want) || !reflect.DeepEqual(iterated, want) {
+		t.Fatalf("unexpected groups: pulled %v, iterated %v", pulled, iterated)
+	}
+
+	// 迭代时的上下文传递至每个请求
+	for _, req := range client.requests {
+		if req.Context.Value(ctxKey{}) != "iter" {
+			t.Fatalf("request %s did not receive the iteration context", req.Command)
+		}
+	}
+}
+
+// fakeHistory 模拟群历史消息拉取，latest 为当前最新的消息 Seq，按 ReqMsgSeq 及 ReqMsgNumber 从新到旧返回
+func fakeHistory(latest *int) func(req map[string]interface{}) string {
+	return func(req map[string]interface{}) string {
+		from := *latest
+		if seq, ok := req["ReqMsgSeq"].(float64); ok && seq > 0 {
+			from = int(seq)
+		}
+		limit := int(req["ReqMsgNumber"].(float64))
+
+		items := make([]string, 0, limit)
+		seq := from
+		for ; seq > 0 && len(items) < limit; seq-- {
+			items = append(items, fmt.Sprintf(`{"MsgSeq":%d,"MsgBody":[{"MsgType":"TIMTextElem","MsgContent":{"Text":"%d"}}]}`, seq, seq))
+		}
+
+		finished := 0
+		if seq <= 0 {
+			finished = 1
+		}
+
+		return fmt.Sprintf(`{"ActionStatus":"OK","IsFinished":%d,"RspMsgList":[%s]}`, finished, strings.Join(items, ","))
+	}
+}
+
+func TestAPI_IterMessages(t *testing.T) {
+	latest := 3
+	client := newFakeClient(map[string]func(map[string]interface{}) string{
+		commandGetGroupSimpleMsg: fakeHistory(&latest),
+	})
+	a := NewAPI(client)
+
+	pager := a.IterMessages("g1", 2)
+	var seqs []int
+	for message, err := range pager.All(context.Background()) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		seqs = append(seqs, message.GetSeq())
+		if len(seqs) == 1 {
+			break
+		}
+	}
+
+	if c := pager.Cursor(); c.Page.MsgSeq != 3 || c.Offset != 1 {
+		t.Fatalf("cursor = %+v, want pinned to seq 3 with offset 1", c)
+	}
+
+	// 以保存的游标续拉，已迭代的消息不会重复产出
+	pager = a.IterMessages("g1", 2, pager.Cursor())
+	for message, err := range pager.All(context.Background()) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		seqs = append(seqs, message.GetSeq())
+	}
+
+	if !reflect.DeepEqual(seqs, []int{3, 2, 1}) || !pager.Done() {
+		t.Fatalf("unexpected seqs: %v", seqs)
+	}
+
+	cursor := pagination.Cursor[MessagesCursor]{Page: MessagesCursor{GroupId: "g2", MsgSeq: 8}}
+	if _, err := pagination.Collect(a.IterMessages("g1", 1, cursor).All(context.Background())); err != errCursorGroupMismatch {
+		t.Fatalf("expected cursor mismatch error, got %v", err)
+	}
+}
+
+func TestAPI_IterMessagesResumeAfterNewMessages(t *testing.T) {
+	latest := 5
+	client := newFakeClient(map[string]func(map[string]interface{}) string{
+		commandGetGroupSimpleMsg: fakeHistory(&latest),
+	})
+	a := NewAPI(client)
+
+	checkpoint := pagination.NewMemoryCheckpoint[MessagesCursor]()
+	pager := a.IterMessages("g1", 3)
+
+	var seqs []int
+	for message, err := range pager.All(context.Background()) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		seqs = append(seqs, message.GetSeq())
+		if len(seqs) == 2 {
+			break
+		}
+	}
+	if err := checkpoint.Save(pager.Cursor()); err != nil {
+		t.Fatal(err)
+	}
+
+	// 保存游标后群内有新消息到达
+	latest = 9
+
+	saved, _, _ := checkpoint.Load()
+	pager = a.IterMessages("g1", 3, saved)
+	for message, err := range pager.All(context.Background()) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		seqs = append(seqs, message.GetSeq())
+	}
+
+	if !reflect.DeepEqual(seqs, []int{5, 4, 3, 2, 1}) {
+		t.Fatalf("seqs = %v, want [5 4 3 2 1]", seqs)
+	}
+}
+
+func TestAPI_AVChatRoom(t *testing.T) {
+	client := newFakeClient(map[string]func(map[string]interface{}) string{
+		commandSendBroadcastMsg: reply(`{"ActionStatus":"OK","MsgSeq":12}`),
+		commandGetOnlineMembers: func(req map[string]interface{}) string {
+			if req["Timestamp"] == float64(0) {
+				return `{"ActionStatus":"OK","NextTimestamp":100,"MemberList":[{"Member_Account":"u1"},{"Member_Account":"u2"}]}`
+			}
+			return `{"ActionStatus":"OK","NextTimestamp":0,"MemberList":[{"Member_Account":"u3"}]}`
+		},
+		commandBanGroupMember:   reply(`{"ActionStatus":"OK"}`),
+		commandUnbanGroupMember: reply(`{"ActionStatus":"OK"}`),
+	})
+	a := NewAPI(client)
+
+	message := NewMessage()
+	message.SetSender("admin")
+	message.AddContent(types.MsgTextContent{Text: "hello"})
+
+	msgSeq, err := a.SendBroadcastMessage(message)
+	if err != nil || msgSeq != 12 {
+		t.Fatalf("unexpected broadcast result: %d, %v", msgSeq, err)
+	}
+	if body := client.last().Body; body["From_Account"] != "admin" || body["GroupId"] != nil || len(body["MsgBody"].([]interface{})) != 1 {
+		t.Fatalf("unexpected broadcast request: %v", body)
+	}
+
+	members, err := pagination.Collect(a.IterOnlineMembers("@TGS#a1").All(context.Background()))
+	if err != nil || !reflect.DeepEqual(members, []string{"u1", "u2", "u3"}) {
+		t.Fatalf("unexpected online members: %v, %v", members, err)
+	}
+	if body := client.last().Body; body["GroupId"] != "@TGS#a1" || body["Timestamp"] != float64(100) {
+		t.Fatalf("unexpected online members request: %v", body)
+	}
+
+	if err = a.BanOnlineMembers(&BanOnlineMembersArg{GroupId: "@TGS#a1", UserIds: []string{"u1"}}); err != errInvalidBanDuration {
+		t.Fatalf("expected ban duration error, got %v", err)
+	}
+	if err = a.BanOnlineMembers(&BanOnlineMembersArg{GroupId: "@TGS#a1", UserIds: []string{"u1"}, Duration: 60, Description: "spam"}); err != nil {
+		t.Fatal(err)
+	}
+	if body := client.last().Body; !reflect.DeepEqual(body["Members_Account"], []interface{}{"u1"}) || body["Duration"] != float64(60) || body["Description"] != "spam" {
+		t.Fatalf("unexpected ban request: %v", body)
+	}
+
+	if err = a.BanGroupMember("@TGS#a1", []string{"u2"}, 30); err != nil {
+		t.Fatal(err)
+	}
+	if body := client.last().Body; !reflect.DeepEqual(body["Members_Account"], []interface{}{"u2"}) || body["Duration"] != float64(30) || body["Members"] != nil {
+		t.Fatalf("unexpected ban request: %v", body)
+	}
+
+	if err = a.UnbanOnlineMembers("@TGS#a1", "u1"); err != nil || client.last().Command != commandUnbanGroupMember {
+		t.Fatalf("unexpected unban result: %v", err)
+	}
+}
+
+func TestMsgPriority_Usages(t *testing.T) {
+	for _, priority := range []MsgPriority{MsgPriorityHigh, MsgPriorityNormal, MsgPriorityLow, MsgPriorityLowest} {
+		for _, usage := range priority.Usages() {
+			if RecommendMsgPriority(usage) != priority {
+				t.Fatalf("usage %s should recommend priority %s", usage, priority)
+			}
+		}
+	}
+
+	if RecommendMsgPriority("Unknown") != MsgPriorityNormal {
+		t.Fatal("unknown usage should fall back to normal priority")
+	}
+}
+
+func TestAPI_MessageReceipts(t *testing.T) {
+	client := newFakeClient(map[string]func(map[string]interface{}) string{
+		commandSendGroupMsg:       reply(`{"ActionStatus":"OK","MsgSeq":5,"MsgTime":100}`),
+		commandGetGroupMsgReceipt: reply(`{"ActionStatus":"OK","ReadReceiptList":[{"MsgSeq":5,"ReadNum":2,"UnReadNum":1},{"MsgSeq":6,"ReadNum":0,"UnReadNum":3}]}`),
+		commandGetGroupMsgReceiptDetail: func(req map[string]interface{}) string {
+			if req["Cursor"] == nil {
+				return `{"ActionStatus":"OK","ReadList":[{"Member_Account":"u1"}],"Cursor":"c1","IsFinish":0}`
+			}
+			return `{"ActionStatus":"OK","ReadList":[{"Member_Account":"u2"}],"IsFinish":1}`
+		},
+	})
+	a := NewAPI(client)
+
+	message := NewMessage()
+	message.SetNeedReadReceipt(true)
+	message.AddContent(types.MsgTextContent{Text: "hello"})
+	if _, err := a.SendMessage("@TGS#w1", message); err != nil {
+		t.Fatal(err)
+	}
+	if body := client.last().Body; body["NeedReadReceipt"] != float64(1) {
+		t.Fatalf("read receipt flag not set: %v", body)
+	}
+
+	receipts, err := a.GetMessageReceipts("@TGS#w1", 5, 6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := receipts[5]; r == nil || r.ReadNum != 2 || r.UnreadNum != 1 || receipts[6].UnreadNum != 3 {
+		t.Fatalf("unexpected receipts: %+v", receipts)
+	}
+	if seqs := client.last().Body["MsgSeqList"]; !reflect.DeepEqual(seqs, []interface{}{map[string]interface{}{"MsgSeq": float64(5)}, map[string]interface{}{"MsgSeq": float64(6)}}) {
+		t.Fatalf("unexpected receipt request: %v", seqs)
+	}
+
+	members, err := pagination.Collect(a.IterReceiptMembers(&FetchReceiptMembersArg{GroupId: "@TGS#w1", MsgSeq: 5}).All(context.Background()))
+	if err != nil || !reflect.DeepEqual(members, []string{"u1", "u2"}) {
+		t.Fatalf("unexpected read members: %v, %v", members, err)
+	}
+	if body := client.last().Body; body["Cursor"] != "c1" || body["Num"] != float64(defaultReceiptMembersLimit) || body["Flag"] != float64(ReceiptFlagRead) {
+		t.Fatalf("unexpected receipt detail request: %v", body)
+	}
+
+	if _, err = a.GetMessageReceipts("@TGS#w1"); err != errNotSetMsgSeq {
+		t.Fatalf("expected msg seq error, got %v", err)
+	}
+}
+
+func TestAPI_MessageExtensions(t *testing.T) {
+	client := newFakeClient(map[string]func(map[string]interface{}) string{
+		commandSendGroupMsg: reply(`{"ActionStatus":"OK","MsgSeq":8,"MsgTime":100}`),
+		commandSetGroupMsgExtensions: reply(`{"ActionStatus":"OK","ResultList":[
+			{"ErrorCode":0,"Extension":{"Key":"like","Value":"u1","Seq":3}},
+			{"ErrorCode":23001,"Extension":{"Key":"smile","Value":"u2,u3","Seq":5}}
+		]}`),
+		commandGetGroupMsgExtensions: func(req map[string]interface{}) string {
+			if req["StartSeq"] == float64(1) {
+				return `{"ActionStatus":"OK","Complete":0,"ExtensionList":[{"Key":"like","Value":"u1","Seq":3}]}`
+			}
+			return `{"ActionStatus":"OK","Complete":1,"ExtensionList":[{"Key":"smile","Value":"u2,u3","Seq":5}]}`
+		},
+	})
+	a := NewAPI(client)
+
+	message := NewMessage()
+	message.SetSupportMessageExtension(true)
+	message.AddContent(types.MsgTextContent{Text: "hello"})
+	if _, err := a.SendMessage("@TGS#w1", message); err != nil {
+		t.Fatal(err)
+	}
+	if body := client.last().Body; body["SupportMessageExtension"] != float64(1) {
+		t.Fatalf("message extension flag not set: %v", body)
+	}
+
+	results, err := a.SetMessageExtensions("@TGS#w1", 8, NewMessageExtension("like", "u1"), NewMessageExtension("smile", "u2", 4))
+	var conflict *ExtensionConflictError
+	if !errors.As(err, &conflict) || conflict.Code() != 23001 || conflict.MsgSeq != 8 || len(conflict.Conflicts) != 1 {
+		t.Fatalf("expected extension conflict error, got %v", err)
+	}
+	if current := conflict.Conflicts[0]; current.Key != "smile" || current.Value != "u2,u3" || current.Seq != 5 {
+		t.Fatalf("unexpected conflict: %+v", current)
+	}
+	if len(results) != 1 || results[0].Key != "like" || results[0].Seq != 3 {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+	if list := client.last().Body["ExtensionList"].([]interface{}); list[1].(map[string]interface{})["Seq"] != float64(4) {
+		t.Fatalf("unexpected set request: %v", list)
+	}
+
+	extensions, err := a.GetMessageExtensions("@TGS#w1", 8)
+	if err != nil || len(extensions) != 2 || extensions[1].Value != "u2,u3" {
+		t.Fatalf("unexpected extensions: %+v, %v", extensions, err)
+	}
+	if body := client.last().Body; body["StartSeq"] != float64(4) {
+		t.Fatalf("unexpected get request: %v", body)
+	}
+
+	if _, err = a.SetMessageExtensions("@TGS#w1", 8, NewMessageExtension("like", "u1"), NewMessageExtension("like", "u2")); err != errDuplicateExtensionKey {
+		t.Fatalf("expected duplicate key error, got %v", err)
+	}
+}
+
+func TestAPI_MessageExtensionsFailed(t *testing.T) {
+	client := newFakeClient(map[string]func(map[string]interface{}) string{
+		commandSetGroupMsgExtensions: reply(`{"ActionStatus":"OK","ResultList":[
+			{"ErrorCode":23001,"Extension":{"Key":"like","Value":"u1","Seq":3}},
+			{"ErrorCode":10004,"Extension":{"Key":"smile","Value":"u2","Seq":4}}
+		]}`),
+	})
+	a := NewAPI(client)
+
+	results, err := a.SetMessageExtensions("@TGS#w1", 8, NewMessageExtension("like", "u1", 2), NewMessageExtension("smile", "u2", 4))
+	var conflict *ExtensionConflictError
+	if errors.As(err, &conflict) {
+		t.Fatalf("expected a generic error, got conflict %v", err)
+	}
+	if e, ok := err.(core.Error); !ok || e.Code() != 10004 || !strings.Contains(e.Message(), "smile") {
+		t.Fatalf("expected the failed extension error, got %v", err)
+	}
+	if len(results) != 0 {
+		t.Fatalf("unexpected results: %+v", results)
+	}
+}
+
+func TestAPI_MessageExtensionsStalled(t *testing.T) {
+	client := newFakeClient(map[string]func(map[string]interface{}) string{
+		commandGetGroupMsgExtensions: reply(`{"ActionStatus":"OK","Complete":0,"ExtensionList":[{"Key":"like","Value":"u1","Seq":3}]}`),
+	})
+	a := NewAPI(client)
+
+	extensions, err := a.GetMessageExtensions("@TGS#w1", 8)
+	if err != pagination.ErrStalled {
+		t.Fatalf("expected stalled error, got %v", err)
+	}
+	if len(extensions) != 1 || len(client.requests) != 2 {
+		t.Fatalf("unexpected extensions: %+v after %d requests", extensions, len(client.requests))
+	}
+}
+
+func TestAPI_PinnedMessages(t *testing.T) {
+	attrs := map[string]string{}
+	a := NewAPI(attrClient(attrs, map[string]func(map[string]interface{}) string{}))
+
+	for _, seq := range []int{1, 2, 1} {
+		if err := a.PinMessage("g1", seq, "admin"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	pinned, err := a.GetPinnedMessages("g1")
+	if err != nil || len(pinned) != 2 || pinned[0].MsgSeq != 1 || pinned[1].MsgSeq != 2 || pinned[0].Operator != "admin" {
+		t.Fatalf("unexpected pinned messages: %+v, %v", pinned, err)
+	}
+
+	if err = a.UnpinMessage("g1", 1); err != nil {
+		t.Fatal(err)
+	}
+	if pinned, _ = a.GetPinnedMessages("g1"); len(pinned) != 1 || pinned[0].MsgSeq != 2 {
+		t.Fatalf("unexpected pinned messages after unpin: %+v", pinned)
+	}
+
+	for seq := 3; seq < 3+maxPinnedMessages-1; seq++ {
+		if err = a.PinMessage("g1", seq); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = a.PinMessage("g1", 100); err != errTooManyPinned {
+		t.Fatalf("expected too many pinned error, got %v", err)
+	}
+}
+
+func TestAPI_PinMessageConcurrentUpdate(t *testing.T) {
+	attrs := map[string]string{}
+	client := attrClient(attrs, map[string]func(map[string]interface{}) string{})
+	modify := client.handlers[commandModifyGroupAttr]
+
+	// 模拟其他进程基于旧列表写入，覆盖首次置顶
+	overwrites := 1
+	client.handlers[commandModifyGroupAttr] = func(req map[string]interface{}) string {
+		resp := modify(req)
+		if overwrites > 0 {
+			overwrites--
+			attrs[AttrKeyPinnedMessages] = `[{"msg_seq":9,"pin_time":1}]`
+		}
+		return resp
+	}
+	a := NewAPI(client)
+
+	if err := a.PinMessage("g1", 1); err != nil {
+		t.Fatal(err)
+	}
+	if pinned, _ := a.GetPinnedMessages("g1"); len(pinned) != 2 || pinned[0].MsgSeq != 1 || pinned[1].MsgSeq != 9 {
+		t.Fatalf("expected pin to be retried on the latest list: %+v", pinned)
+	}
+
+	overwrites = maxAttrUpdateAttempts
+	if err := a.PinMessage("g1", 2); err != ErrAttrUpdateConflict {
+		t.Fatalf("expected conflict error, got %v", err)
+	}
+}
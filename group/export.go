@@ -0,0 +1,378 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 群历史消息导出
+ */
+
+package group
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/d60-Lab/tencent-im/internal/types"
+	"github.com/d60-Lab/tencent-im/pagination"
+)
+
+const (
+	ExportFormatJSONL ExportFormat = "jsonl" // JSON Lines，每行一条消息
+	ExportFormatCSV   ExportFormat = "csv"   // CSV，消息体以 JSON 字符串写入 body 列
+)
+
+const (
+	defaultExportDir      = "group_export"
+	defaultExportMaxSize  = 100 << 20
+	defaultExportLimit    = 20
+	exportFileTimeLayout  = "20060102T150405.000000000"
+	exportFilePrefixGroup = "group_"
+)
+
+var exportCSVHeader = []string{"group_id", "msg_seq", "sender", "timestamp", "priority", "status", "placeholder", "random", "custom_data", "body"}
+
+type (
+	// ExportFormat 导出格式
+	ExportFormat string
+
+	// ExportOptions 群历史消息导出配置
+	ExportOptions struct {
+		Dir              string                              // （选填）导出目录，默认为 group_export
+		Prefix           string                              // （选填）导出文件名前缀，默认为 group_{群ID}
+		Format           ExportFormat                        // （选填）导出格式，默认为 JSONL
+		MaxSize          int64                               // （选填）单个导出文件的最大字节数，超过后切换至新文件，默认为100MB
+		MaxAge           time.Duration                       // （选填）单个导出文件的最长写入时长，超过后切换至新文件，默认不按时长切换
+		Limit            int                                 // （选填）单次拉取的消息条数，默认为20
+		StartTime        time.Time                           // （选填）导出时间范围的起点，按 Seq 倒序拉取至早于该时间的消息时停止
+		EndTime          time.Time                           // （选填）导出时间范围的终点，晚于该时间的消息将被跳过
+		SkipPlaceholders bool                                // （选填）是否跳过占位消息（过期、删除或存储失败的消息）
+		Checkpoint       pagination.Checkpoint[ExportCursor] // （选填）游标检查点，设置后可断点续导
+		CheckpointEvery  int                                 // （选填）每导出多少条消息保存一次游标，默认为100
+	}
+
+	// ExportRecord 群消息导出记录
+	ExportRecord struct {
+		GroupId     string           `json:"group_id"`              // 群ID
+		MsgSeq      int              `json:"msg_seq"`               // 消息序列号
+		Sender      string           `json:"sender"`                // 消息发送者
+		Timestamp   int64            `json:"timestamp"`             // 消息时间戳，UNIX 时间戳（单位：秒）
+		Priority    MsgPriority      `json:"priority,omitempty"`    // 消息优先级
+		Status      MsgStatus        `json:"status"`                // 消息状态
+		Placeholder bool             `json:"placeholder"`           // 是否为占位消息
+		Random      uint32           `json:"random"`                // 消息随机数
+		CustomData  string           `json:"custom_data,omitempty"` // 消息自定义数据
+		Body        []*types.MsgBody `json:"body"`                  // 消息体
+	}
+
+	// ExportResult 群历史消息导出结果
+	ExportResult struct {
+		Messages     int      // 写入的消息数（含占位消息）
+		Placeholders int      // 拉取到的占位消息数（含跳过的）
+		Gaps         int      // 相邻消息之间未返回的 Seq 数量
+		FirstSeq     int      // 写入的最大 Seq
+		LastSeq      int      // 写入的最小 Seq
+		Files        []string // 本次写入的文件
+	}
+
+	// ExportCursor 群历史消息导出游标，在消息游标之外记录续导时统计缺口及判断时间范围所需的状态
+	ExportCursor struct {
+		MessagesCursor
+		PrevSeq int  `json:"prev_seq"` // 最近一条已处理消息的 Seq
+		InRange bool `json:"in_range"` // 是否已进入导出时间范围
+	}
+
+	// exportState 导出过程中的状态
+	exportState struct {
+		prevSeq int
+		inRange bool
+	}
+
+	// exportCheckpoint 将导出状态与消息游标一同保存的检查点
+	exportCheckpoint struct {
+		checkpoint pagination.Checkpoint[ExportCursor]
+		state      *exportState
+	}
+
+	// exportWriter 按大小及时长切换文件的导出写入器
+	exportWriter struct {
+		opt      ExportOptions
+		file     *os.File
+		buf      *bufio.Writer
+		size     int64
+		openedAt time.Time
+		files    []string
+	}
+)
+
+// ExportMessages 导出群历史消息
+// 本方法由“拉取群历史消息（FetchMessages）”拓展而来，按 Seq 从新到旧将消息写入 JSONL 或 CSV 文件。
+// 设置 StartTime 时拉取至早于该时间的消息即停止；占位消息（IsPlaceMsg 不为0）默认以空消息体写入，以便审计时区分缺失的消息。
+func ExportMessages(ctx context.Context, api API, groupId string, opt ExportOptions) (ret *ExportResult, err error) {
+	if opt.Dir == "" {
+		opt.Dir = defaultExportDir
+	}
+	if opt.Prefix == "" {
+		opt.Prefix = exportFilePrefixGroup + sanitizeFileName(groupId)
+	}
+	if opt.Format == "" {
+		opt.Format = ExportFormatJSONL
+	}
+	if opt.Format != ExportFormatJSONL && opt.Format != ExportFormatCSV {
+		err = fmt.Errorf("unsupported export format %q", opt.Format)
+		return
+	}
+	if opt.MaxSize <= 0 {
+		opt.MaxSize = defaultExportMaxSize
+	}
+	if opt.Limit <= 0 {
+		opt.Limit = defaultExportLimit
+	}
+	if opt.Checkpoint == nil {
+		opt.Checkpoint = pagination.NewMemoryCheckpoint[ExportCursor]()
+	}
+
+	if err = os.MkdirAll(opt.Dir, 0755); err != nil {
+		return
+	}
+
+	var (
+		w     = &exportWriter{opt: opt}
+		state = &exportState{inRange: opt.EndTime.IsZero()}
+	)
+
+	ret = &ExportResult{}
+
+	checkpoint := &exportCheckpoint{checkpoint: opt.Checkpoint, state: state}
+	err = pagination.Export(ctx, api.IterMessages(groupId, opt.Limit), checkpoint, func(message *Message) error {
+		placeholder := message.status != MsgStatusNormal
+
+		gaps := 0
+		if state.prevSeq > 0 && message.seq < state.prevSeq-1 {
+			gaps = state.prevSeq - message.seq - 1
+		}
+
+		// 占位消息没有可靠的时间戳，以前后正常消息的时间判断其是否处于导出时间范围内
+		next, skip := exportState{prevSeq: message.seq, inRange: state.inRange}, false
+		if !placeholder {
+			if !opt.StartTime.IsZero() && message.timestamp < opt.StartTime.Unix() {
+				ret.Gaps += gaps
+				return pagination.ErrStop
+			}
+			skip = !opt.EndTime.IsZero() && message.timestamp > opt.EndTime.Unix()
+			next.inRange = next.inRange || !skip
+		} else {
+			skip = !state.inRange || opt.SkipPlaceholders
+		}
+
+		if !skip {
+			if err := w.write(newExportRecord(groupId, message)); err != nil {
+				return err
+			}
+
+			if ret.Messages == 0 {
+				ret.FirstSeq = message.seq
+			}
+			ret.LastSeq = message.seq
+			ret.Messages++
+		}
+
+		// 统计及状态仅在消息处理成功后更新，保证与保存的游标一致
+		if placeholder && state.inRange {
+			ret.Placeholders++
+		}
+		ret.Gaps += gaps
+		*state = next
+
+		return nil
+	}, pagination.ExportOptions{Every: opt.CheckpointEvery, Flush: w.flush})
+
+	if closeErr := w.close(); err == nil {
+		err = closeErr
+	}
+
+	ret.Files = w.files
+
+	return
+}
+
+// Load 加载已保存的游标并恢复导出状态
+func (c *exportCheckpoint) Load() (cursor pagination.Cursor[MessagesCursor], ok bool, err error) {
+	saved, ok, err := c.checkpoint.Load()
+	if err != nil || !ok {
+		return
+	}
+
+	c.state.prevSeq, c.state.inRange = saved.Page.PrevSeq, c.state.inRange || saved.Page.InRange
+	cursor = pagination.Cursor[MessagesCursor]{Page: saved.Page.MessagesCursor, Offset: saved.Offset, Done: saved.Done}
+
+	return
+}
+
+// Save 将消息游标与当前导出状态一同保存
+func (c *exportCheckpoint) Save(cursor pagination.Cursor[MessagesCursor]) error {
+	return c.checkpoint.Save(pagination.Cursor[ExportCursor]{
+		Page:   ExportCursor{MessagesCursor: cursor.Page, PrevSeq: c.state.prevSeq, InRange: c.state.inRange},
+		Offset: cursor.Offset,
+		Done:   cursor.Done,
+	})
+}
+
+// newExportRecord 新建群消息导出记录
+func newExportRecord(groupId string, message *Message) *ExportRecord {
+	record := &ExportRecord{
+		GroupId:     groupId,
+		MsgSeq:      message.seq,
+		Sender:      message.GetSender(),
+		Timestamp:   message.timestamp,
+		Priority:    message.priority,
+		Status:      message.status,
+		Placeholder: message.status != MsgStatusNormal,
+		Random:      message.GetRandom(),
+		Body:        message.GetBody(),
+	}
+
+	if data, ok := message.GetCustomData().(string); ok {
+		record.CustomData = data
+	}
+
+	if record.Body == nil {
+		record.Body = []*types.MsgBody{}
+	}
+
+	return record
+}
+
+// write 写入导出记录
+func (w *exportWriter) write(record *ExportRecord) error {
+	b, err := w.encode(record)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if w.file == nil || (w.size > 0 && w.size+int64(len(b)) > w.opt.MaxSize) || (w.opt.MaxAge > 0 && now.Sub(w.openedAt) >= w.opt.MaxAge) {
+		if err = w.rotate(now); err != nil {
+			return err
+		}
+	}
+
+	n, err := w.buf.Write(b)
+	w.size += int64(n)
+
+	return err
+}
+
+// encode 按导出格式编码导出记录
+func (w *exportWriter) encode(record *ExportRecord) ([]byte, error) {
+	if w.opt.Format == ExportFormatJSONL {
+		b, err := json.Marshal(record)
+		if err != nil {
+			return nil, err
+		}
+		return append(b, '\n'), nil
+	}
+
+	body, err := json.Marshal(record.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return encodeCSV([]string{
+		record.GroupId,
+		strconv.Itoa(record.MsgSeq),
+		record.Sender,
+		strconv.FormatInt(record.Timestamp, 10),
+		string(record.Priority),
+		strconv.Itoa(int(record.Status)),
+		strconv.FormatBool(record.Placeholder),
+		strconv.FormatUint(uint64(record.Random), 10),
+		record.CustomData,
+		string(body),
+	})
+}
+
+// rotate 切换导出文件
+func (w *exportWriter) rotate(now time.Time) error {
+	if err := w.close(); err != nil {
+		return err
+	}
+
+	name := filepath.Join(w.opt.Dir, fmt.Sprintf("%s-%s.%s", w.opt.Prefix, now.UTC().Format(exportFileTimeLayout), w.opt.Format))
+	file, err := os.OpenFile(name, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+
+	w.file, w.buf, w.size, w.openedAt = file, bufio.NewWriter(file), 0, now
+	w.files = append(w.files, name)
+
+	if w.opt.Format == ExportFormatCSV {
+		header, err := encodeCSV(exportCSVHeader)
+		if err != nil {
+			return err
+		}
+		n, err := w.buf.Write(header)
+		w.size += int64(n)
+		return err
+	}
+
+	return nil
+}
+
+// flush 将缓冲的记录落盘
+func (w *exportWriter) flush() error {
+	if w.file == nil {
+		return nil
+	}
+
+	if err := w.buf.Flush(); err != nil {
+		return err
+	}
+
+	return w.file.Sync()
+}
+
+// close 关闭当前导出文件
+func (w *exportWriter) close() error {
+	if w.file == nil {
+		return nil
+	}
+
+	err := w.flush()
+	if closeErr := w.file.Close(); err == nil {
+		err = closeErr
+	}
+	w.file, w.buf = nil, nil
+
+	return err
+}
+
+// encodeCSV 编码一行 CSV 记录
+func encodeCSV(fields []string) ([]byte, error) {
+	var b bytes.Buffer
+
+	cw := csv.NewWriter(&b)
+	if err := cw.Write(fields); err != nil {
+		return nil, err
+	}
+	cw.Flush()
+
+	return b.Bytes(), cw.Error()
+}
+
+// sanitizeFileName 将群ID中不适合作为文件名的字符替换为下划线
+func sanitizeFileName(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == '_' || r == '.' || (r >= '0' && r <= '9') || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') {
+			return r
+		}
+		return '_'
+	}, s)
+}
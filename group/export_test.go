@@ -0,0 +1,257 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 群历史消息导出单元测试
+ */
+
+package group
+
+import (
+	"bufio"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/d60-Lab/tencent-im/internal/types"
+	"github.com/d60-Lab/tencent-im/pagination"
+)
+
+// historyClient 以 Seq 7 至 1 的历史消息应答拉取请求，其中 Seq 4 为占位消息，Seq 2 缺失
+func historyClient() *fakeClient {
+	history := []map[string]interface{}{
+		{"MsgSeq": 7, "MsgTimeStamp": 700, "From_Account": "u7", "MsgBody": []interface{}{map[string]interface{}{"MsgType": "TIMTextElem", "MsgContent": map[string]interface{}{"Text": "7"}}}},
+		{"MsgSeq": 6, "MsgTimeStamp": 600, "From_Account": "u6", "MsgPriority": 1, "MsgBody": []interface{}{map[string]interface{}{"MsgType": "TIMTextElem", "MsgContent": map[string]interface{}{"Text": "6"}}}},
+		{"MsgSeq": 5, "MsgTimeStamp": 500, "From_Account": "u5"},
+		{"MsgSeq": 4, "IsPlaceMsg": 1},
+		{"MsgSeq": 3, "MsgTimeStamp": 300, "From_Account": "u3"},
+		{"MsgSeq": 1, "MsgTimeStamp": 100, "From_Account": "u1"},
+	}
+
+	return newFakeClient(map[string]func(map[string]interface{}) string{
+		commandGetGroupSimpleMsg: func(req map[string]interface{}) string {
+			seq, _ := req["ReqMsgSeq"].(float64)
+			if seq == 0 {
+				seq = 7
+			}
+
+			limit, _ := req["ReqMsgNumber"].(float64)
+
+			list := make([]map[string]interface{}, 0, int(limit))
+			for _, item := range history {
+				if item["MsgSeq"].(int) <= int(seq) && len(list) < int(limit) {
+					list = append(list, item)
+				}
+			}
+
+			b, _ := json.Marshal(map[string]interface{}{"ActionStatus": "OK", "IsFinished": 1, "RspMsgList": list})
+			return string(b)
+		},
+	})
+}
+
+func TestExportMessages_JSONL(t *testing.T) {
+	dir := t.TempDir()
+
+	ret, err := ExportMessages(context.Background(), NewAPI(historyClient()), "@TGS#1", ExportOptions{
+		Dir:       dir,
+		Limit:     2,
+		StartTime: time.Unix(200, 0),
+		EndTime:   time.Unix(650, 0),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ret.Messages != 4 || ret.Placeholders != 1 || ret.Gaps != 1 || ret.FirstSeq != 6 || ret.LastSeq != 3 || len(ret.Files) != 1 {
+		t.Fatalf("unexpected result: %+v", ret)
+	}
+
+	file, err := os.Open(ret.Files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	var records []*ExportRecord
+	for scanner := bufio.NewScanner(file); scanner.Scan(); {
+		record := &ExportRecord{}
+		if err = json.Unmarshal(scanner.Bytes(), record); err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+
+	seqs := make([]int, 0, len(records))
+	for _, record := range records {
+		seqs = append(seqs, record.MsgSeq)
+	}
+	if !reflect.DeepEqual(seqs, []int{6, 5, 4, 3}) {
+		t.Fatalf("unexpected seqs: %v", seqs)
+	}
+
+	if r := records[0]; r.Sender != "u6" || r.Timestamp != 600 || r.Priority != MsgPriorityHigh || len(r.Body) != 1 {
+		t.Fatalf("unexpected record: %+v", r)
+	}
+	if text, ok := records[0].Body[0].MsgContent.(*types.MsgTextContent); !ok || text.Text != "6" {
+		t.Fatalf("unexpected body: %#v", records[0].Body[0].MsgContent)
+	}
+	if r := records[2]; !r.Placeholder || r.Status != MsgStatusInvalid || len(r.Body) != 0 {
+		t.Fatalf("unexpected placeholder record: %+v", r)
+	}
+}
+
+func TestExportMessages_CSVRotation(t *testing.T) {
+	ret, err := ExportMessages(context.Background(), NewAPI(historyClient()), "@TGS#1", ExportOptions{
+		Dir:              t.TempDir(),
+		Format:           ExportFormatCSV,
+		Limit:            2,
+		MaxSize:          1,
+		SkipPlaceholders: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ret.Messages != 5 || ret.Placeholders != 1 || len(ret.Files) != 5 {
+		t.Fatalf("unexpected result: %+v", ret)
+	}
+
+	file, err := os.Open(ret.Files[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+
+	rows, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 2 || !reflect.DeepEqual(rows[0], exportCSVHeader) || rows[1][1] != "7" || rows[1][2] != "u7" {
+		t.Fatalf("unexpected rows: %v", rows)
+	}
+}
+
+func TestExportMessages_Resume(t *testing.T) {
+	client := historyClient()
+	fetch := client.handlers[commandGetGroupSimpleMsg]
+
+	// 拉取 Seq 4 及 Seq 2 时各失败一次，分别在占位消息之前及缺口之前中断
+	failed := map[float64]bool{}
+	client.handlers[commandGetGroupSimpleMsg] = func(req map[string]interface{}) string {
+		if seq, _ := req["ReqMsgSeq"].(float64); (seq == 4 || seq == 2) && !failed[seq] {
+			failed[seq] = true
+			return `{"ActionStatus":"FAIL","ErrorCode":10004,"ErrorInfo":"busy"}`
+		}
+		return fetch(req)
+	}
+
+	dir := t.TempDir()
+	opt := ExportOptions{
+		Dir:        dir,
+		Limit:      1,
+		StartTime:  time.Unix(200, 0),
+		EndTime:    time.Unix(650, 0),
+		Checkpoint: pagination.NewFileCheckpoint[ExportCursor](filepath.Join(dir, "cursor.json")),
+	}
+
+	var (
+		seqs         []int
+		gaps         int
+		placeholders int
+		err          error
+	)
+	for i := 0; i < 3; i++ {
+		var ret *ExportResult
+		ret, err = ExportMessages(context.Background(), NewAPI(client), "@TGS#1", opt)
+		gaps += ret.Gaps
+		placeholders += ret.Placeholders
+
+		for _, name := range ret.Files {
+			file, _ := os.Open(name)
+			for scanner := bufio.NewScanner(file); scanner.Scan(); {
+				record := &ExportRecord{}
+				_ = json.Unmarshal(scanner.Bytes(), record)
+				seqs = append(seqs, record.MsgSeq)
+			}
+			_ = file.Close()
+		}
+
+		if err == nil {
+			break
+		}
+		if errors.Is(err, pagination.ErrStop) {
+			t.Fatalf("unexpected stop error: %v", err)
+		}
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(seqs, []int{6, 5, 4, 3}) || gaps != 1 || placeholders != 1 {
+		t.Fatalf("unexpected export: seqs %v, gaps %d, placeholders %d", seqs, gaps, placeholders)
+	}
+
+	// 导出已完成，再次调用不会写入重复的消息
+	ret, err := ExportMessages(context.Background(), NewAPI(client), "@TGS#1", opt)
+	if err != nil || ret.Messages != 0 {
+		t.Fatalf("unexpected re-export: %+v, %v", ret, err)
+	}
+}
+
+func TestExportMessages_ResumeAfterNewMessages(t *testing.T) {
+	latest := 5
+	client := newFakeClient(map[string]func(map[string]interface{}) string{})
+	fetch := fakeHistory(&latest)
+
+	// 拉取第二页时失败一次，使导出在首页处理完成后中断
+	failed := false
+	client.handlers[commandGetGroupSimpleMsg] = func(req map[string]interface{}) string {
+		if seq, _ := req["ReqMsgSeq"].(float64); seq == 2 && !failed {
+			failed = true
+			return `{"ActionStatus":"FAIL","ErrorCode":10004,"ErrorInfo":"busy"}`
+		}
+		return fetch(req)
+	}
+
+	dir := t.TempDir()
+	opt := ExportOptions{
+		Dir:        dir,
+		Limit:      3,
+		Checkpoint: pagination.NewFileCheckpoint[ExportCursor](filepath.Join(dir, "cursor.json")),
+	}
+
+	var seqs []int
+	collect := func(ret *ExportResult) {
+		for _, name := range ret.Files {
+			file, _ := os.Open(name)
+			for scanner := bufio.NewScanner(file); scanner.Scan(); {
+				record := &ExportRecord{}
+				_ = json.Unmarshal(scanner.Bytes(), record)
+				seqs = append(seqs, record.MsgSeq)
+			}
+			_ = file.Close()
+		}
+	}
+
+	ret, err := ExportMessages(context.Background(), NewAPI(client), "@TGS#1", opt)
+	if err == nil {
+		t.Fatal("expected the first export to be interrupted")
+	}
+	collect(ret)
+
+	// 续导前群内有新消息到达
+	latest = 8
+
+	if ret, err = ExportMessages(context.Background(), NewAPI(client), "@TGS#1", opt); err != nil {
+		t.Fatal(err)
+	}
+	collect(ret)
+
+	if !reflect.DeepEqual(seqs, []int{5, 4, 3, 2, 1}) {
+		t.Fatalf("seqs = %v, want [5 4 3 2 1]", seqs)
+	}
+}
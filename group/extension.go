@@ -0,0 +1,97 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 群消息扩展实体类
+ */
+
+package group
+
+import (
+	"fmt"
+
+	"github.com/d60-Lab/tencent-im/internal/core"
+	"github.com/d60-Lab/tencent-im/internal/enum"
+)
+
+const maxExtensionsPerRequest = 20 // 单次设置的消息扩展数量上限
+
+var (
+	errNotSetExtensions      = core.NewError(enum.InvalidParamsCode, "message extensions are not set")
+	errNotSetExtensionKey    = core.NewError(enum.InvalidParamsCode, "message extension key is not set")
+	errTooManyExtensions     = core.NewError(enum.InvalidParamsCode, fmt.Sprintf("the number of message extensions cannot exceed %d", maxExtensionsPerRequest))
+	errDuplicateExtensionKey = core.NewError(enum.InvalidParamsCode, "duplicate message extension key")
+)
+
+type (
+	// MessageExtension 群消息扩展
+	// Seq 为扩展的版本号，新增扩展时填0，修改已有扩展时需填入最近一次获取到的 Seq，服务端以此做乐观并发控制
+	MessageExtension struct {
+		Key   string // 扩展的 Key
+		Value string // 扩展的 Value
+		Seq   int64  // 扩展的版本号
+	}
+
+	// ExtensionConflictError 消息扩展 Seq 冲突错误
+	// 设置消息扩展时携带的 Seq 与服务端不一致，Conflicts 为服务端当前的扩展，合并后以其 Seq 重试即可
+	ExtensionConflictError struct {
+		GroupId   string              // 群ID
+		MsgSeq    int                 // 消息序列号
+		ErrorCode int                 // 服务端返回的错误码（首个冲突扩展）
+		Conflicts []*MessageExtension // 发生冲突的扩展在服务端的当前值
+	}
+)
+
+// NewMessageExtension 新建群消息扩展
+func NewMessageExtension(key, value string, seq ...int64) *MessageExtension {
+	extension := &MessageExtension{Key: key, Value: value}
+	if len(seq) > 0 {
+		extension.Seq = seq[0]
+	}
+
+	return extension
+}
+
+// Error 错误信息
+func (e *ExtensionConflictError) Error() string {
+	return fmt.Sprintf("code: %d, message: %s", e.Code(), e.Message())
+}
+
+// Code 错误码
+func (e *ExtensionConflictError) Code() int {
+	return e.ErrorCode
+}
+
+// Message 错误描述
+func (e *ExtensionConflictError) Message() string {
+	keys := make([]string, 0, len(e.Conflicts))
+	for _, extension := range e.Conflicts {
+		keys = append(keys, extension.Key)
+	}
+
+	return fmt.Sprintf("message extension seq conflict on group %s msg seq %d, keys: %v", e.GroupId, e.MsgSeq, keys)
+}
+
+// 检测设置错误
+func checkExtensionsSetError(extensions []*MessageExtension) error {
+	if len(extensions) == 0 {
+		return errNotSetExtensions
+	}
+
+	if len(extensions) > maxExtensionsPerRequest {
+		return errTooManyExtensions
+	}
+
+	keys := make(map[string]bool, len(extensions))
+	for _, extension := range extensions {
+		if extension.Key == "" {
+			return errNotSetExtensionKey
+		}
+
+		if keys[extension.Key] {
+			return errDuplicateExtensionKey
+		}
+		keys[extension.Key] = true
+	}
+
+	return nil
+}
@@ -8,6 +8,7 @@
 package group
 
 import (
+	"strings"
 	"time"
 
 	"github.com/d60-Lab/tencent-im/internal/core"
@@ -35,10 +36,11 @@ type (
 )
 
 const (
-	TypePublic   Type = "Public"     // Public（陌生人社交群）
-	TypePrivate  Type = "Private"    // Private（即 Work，好友工作群）
-	TypeChatRoom Type = "ChatRoom"   // ChatRoom（即 Meeting，会议群）
-	TypeLiveRoom Type = "AVChatRoom" // AVChatRoom（直播群）
+	TypePublic    Type = "Public"     // Public（陌生人社交群）
+	TypePrivate   Type = "Private"    // Private（即 Work，好友工作群）
+	TypeChatRoom  Type = "ChatRoom"   // ChatRoom（即 Meeting，会议群）
+	TypeLiveRoom  Type = "AVChatRoom" // AVChatRoom（直播群）
+	TypeCommunity Type = "Community"  // Community（社群）
 
 	ApplyJoinOptionFreeAccess     ApplyJoinOption = "FreeAccess"     // 自由加入
 	ApplyJoinOptionNeedPermission ApplyJoinOption = "NeedPermission" // 需要验证
@@ -67,6 +69,7 @@ type Group struct {
 	lastMsgTime     int64                  // 群内最后一条消息的时间
 	nextMsgSeq      int                    // 群内下一条消息的Seq
 	shutUpStatus    string                 // 群全员禁言状态
+	supportTopic    bool                   // 是否支持话题
 }
 
 func NewGroup(id ...string) *Group {
@@ -250,6 +253,16 @@ func (g *Group) GetShutUpStatus() string {
 	return g.shutUpStatus
 }
 
+// SetSupportTopic 设置是否支持话题，仅社群（Community）支持话题
+func (g *Group) SetSupportTopic(supportTopic bool) {
+	g.supportTopic = supportTopic
+}
+
+// IsSupportTopic 获取是否支持话题
+func (g *Group) IsSupportTopic() bool {
+	return g.supportTopic
+}
+
 // SetCreateTime 设置群组创建时间
 func (g *Group) SetCreateTime(createTime int64) {
 	g.createTime = createTime
@@ -283,6 +296,10 @@ func (g *Group) checkCreateError() (err error) {
 		return
 	}
 
+	if err = g.checkCommunityArgError(); err != nil {
+		return
+	}
+
 	if err = g.checkNameArgError(); err != nil {
 		return
 	}
@@ -321,8 +338,11 @@ func (g *Group) checkImportError() (err error) {
 
 // 检测更新错误
 func (g *Group) checkUpdateError() (err error) {
-	if err = g.checkNameArgError(); err != nil {
-		return
+	// 修改群基础资料时群名称为选填，仅在设置时校验
+	if g.name != "" {
+		if err = g.checkNameArgError(); err != nil {
+			return
+		}
 	}
 
 	if err = g.checkIntroductionArgError(); err != nil {
@@ -356,7 +376,7 @@ func (g *Group) checkTypeArgError() error {
 	}
 
 	switch Type(g.groupType) {
-	case TypePublic, TypePrivate, TypeChatRoom, TypeLiveRoom:
+	case TypePublic, TypePrivate, TypeChatRoom, TypeLiveRoom, TypeCommunity:
 	default:
 		return errInvalidGroupType
 	}
@@ -364,6 +384,22 @@ func (g *Group) checkTypeArgError() error {
 	return nil
 }
 
+// 检测社群参数错误
+func (g *Group) checkCommunityArgError() error {
+	if g.groupType != TypeCommunity {
+		if g.supportTopic {
+			return errTopicNotSupported
+		}
+		return nil
+	}
+
+	if g.id != "" && !strings.HasPrefix(g.id, communityGroupIdPrefix) {
+		return errInvalidCommunityGroupId
+	}
+
+	return nil
+}
+
 // 检测群简介参数错误
 func (g *Group) checkIntroductionArgError() error {
 	if len(g.introduction) > 240 {
@@ -23,6 +23,10 @@ const (
 	MsgFlagAcceptAndNotify MsgFlag = "AcceptAndNotify" // 接收并提示
 	MsgFlagAcceptNotNotify MsgFlag = "AcceptNotNotify" // 接收不提示（不会触发 APNs 远程推送）
 	MsgFlagDiscard         MsgFlag = "Discard"         // 屏蔽群消息（不会向客户端推送消息）
+
+	MemberRoleOwner  = "Owner"  // 群主
+	MemberRoleAdmin  = "Admin"  // 群管理员
+	MemberRoleMember = "Member" // 普通成员
 )
 
 type Member struct {
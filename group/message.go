@@ -27,6 +27,12 @@ type (
 
 	// MsgStatus 消息状态
 	MsgStatus int
+
+	// MsgUsage 直播群消息用途
+	MsgUsage string
+
+	// ReceiptFlag 已读回执成员类型
+	ReceiptFlag int
 )
 
 const (
@@ -42,9 +48,45 @@ const (
 	MsgStatusInvalid MsgStatus = 1 // 被删除或者消息过期的消息
 	MsgStatusRevoked MsgStatus = 2 // 被撤回的消息
 
+	MsgUsageRedPacket MsgUsage = "RedPacket" // 红包消息
+	MsgUsageGift      MsgUsage = "Gift"      // 礼物消息
+	MsgUsageChat      MsgUsage = "Chat"      // 普通聊天、弹幕消息
+	MsgUsageLike      MsgUsage = "Like"      // 点赞消息
+	MsgUsageEnter     MsgUsage = "Enter"     // 进场、退场提示消息
+
+	ReceiptFlagRead   ReceiptFlag = 0 // 已读成员
+	ReceiptFlagUnread ReceiptFlag = 1 // 未读成员
+
 	AtAllMembersFlag = "@all" // @所有成员的标识
 )
 
+// 直播群中各优先级推荐承载的消息用途
+var msgPriorityUsages = map[MsgPriority][]MsgUsage{
+	MsgPriorityHigh:   {MsgUsageRedPacket, MsgUsageGift},
+	MsgPriorityNormal: {MsgUsageChat},
+	MsgPriorityLow:    {MsgUsageLike},
+	MsgPriorityLowest: {MsgUsageEnter},
+}
+
+// RecommendMsgPriority 获取直播群中某类消息推荐使用的优先级
+// 直播群消息超过频率限制时，后台优先下发高优先级的消息，未知用途按普通优先级处理
+func RecommendMsgPriority(usage MsgUsage) MsgPriority {
+	for priority, usages := range msgPriorityUsages {
+		for _, u := range usages {
+			if u == usage {
+				return priority
+			}
+		}
+	}
+
+	return MsgPriorityNormal
+}
+
+// Usages 获取该优先级在直播群中推荐承载的消息用途
+func (p MsgPriority) Usages() []MsgUsage {
+	return msgPriorityUsages[p]
+}
+
 type Message struct {
 	entity.Message
 	priority         MsgPriority       // 消息的优先级
@@ -53,10 +95,11 @@ type Message struct {
 	timestamp        int64             // 消息时间戳，UNIX 时间戳（单位：秒）
 	seq              int               // 消息序列号
 	status           MsgStatus         // 消息状态
-	customData       interface{}       // 自定义数据
 	sendControls     map[string]bool   // 发送消息控制
 	callbackControls map[string]bool   // 禁用回调
 	atMembers        map[string]bool   // @用户
+	needReadReceipt  bool              // 是否需要已读回执
+	supportExtension bool              // 是否支持消息扩展
 }
 
 func NewMessage() *Message {
@@ -73,16 +116,6 @@ func (m *Message) GetPriority() MsgPriority {
 	return m.priority
 }
 
-// SetCustomData 设置自定义数据
-func (m *Message) SetCustomData(data interface{}) {
-	m.customData = data
-}
-
-// GetCustomData 获取自定义数据
-func (m *Message) GetCustomData() interface{} {
-	return m.customData
-}
-
 // SetOnlineOnlyFlag 设置仅发送在线成员标识
 func (m *Message) SetOnlineOnlyFlag(flag MsgOnlineOnlyFlag) {
 	m.onlineOnlyFlag = flag
@@ -93,6 +126,28 @@ func (m *Message) GetOnlineOnlyFlag() MsgOnlineOnlyFlag {
 	return m.onlineOnlyFlag
 }
 
+// SetNeedReadReceipt 设置是否需要已读回执
+// 需在控制台开启群消息已读回执功能，仅对工作群（Work）、公开群（Public）、会议群（Meeting）等支持的群类型生效
+func (m *Message) SetNeedReadReceipt(need bool) {
+	m.needReadReceipt = need
+}
+
+// GetNeedReadReceipt 获取是否需要已读回执
+func (m *Message) GetNeedReadReceipt() bool {
+	return m.needReadReceipt
+}
+
+// SetSupportMessageExtension 设置是否支持消息扩展
+// 需在控制台开启消息扩展功能，开启后可通过设置消息扩展接口为该消息添加表情回应等扩展信息
+func (m *Message) SetSupportMessageExtension(support bool) {
+	m.supportExtension = support
+}
+
+// GetSupportMessageExtension 获取是否支持消息扩展
+func (m *Message) GetSupportMessageExtension() bool {
+	return m.supportExtension
+}
+
 // SetSendTime 设置发送时间
 func (m *Message) SetSendTime(sendTime int64) {
 	m.sendTime = sendTime
@@ -194,12 +249,25 @@ func (m *Message) GetTimestamp() int64 {
 	return m.timestamp
 }
 
+// GetSeq 获取消息序列号
+func (m *Message) GetSeq() int {
+	return m.seq
+}
+
 // 检测发送错误
 func (m *Message) checkSendError() (err error) {
+	if err = m.CheckLifeTimeArgError(); err != nil {
+		return
+	}
+
 	if err = m.CheckBodyArgError(); err != nil {
 		return
 	}
 
+	if err = m.CheckOfflinePushArgError(); err != nil {
+		return
+	}
+
 	return
 }
 
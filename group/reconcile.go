@@ -0,0 +1,437 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 声明式群成员同步
+ */
+
+package group
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/d60-Lab/tencent-im/internal/core"
+	"github.com/d60-Lab/tencent-im/internal/enum"
+)
+
+const (
+	ReconcileActionAdd      ReconcileAction = "add"      // 添加成员
+	ReconcileActionRemove   ReconcileAction = "remove"   // 删除成员
+	ReconcileActionUpdate   ReconcileAction = "update"   // 修改成员资料
+	ReconcileActionTransfer ReconcileAction = "transfer" // 转让群主
+)
+
+const (
+	defaultReconcileBatchSize = 100
+	defaultReconcileInterval  = 100 * time.Millisecond
+
+	addMemberResultFailed = 0 // 添加群成员失败
+
+	reconcileCustomFieldPrefix = "AppMemberDefinedData." // 自定义字段在变更字段列表中的前缀
+)
+
+var (
+	errReconcileDuplicateMember = core.NewError(enum.InvalidParamsCode, "duplicate member in desired set")
+	errReconcileMultipleOwners  = core.NewError(enum.InvalidParamsCode, "desired set contains more than one owner")
+	errReconcileAddMemberFailed = core.NewError(enum.InvalidParamsCode, "failed to add member")
+)
+
+type (
+	// ReconcileAction 同步操作
+	ReconcileAction string
+
+	// ReconcileOptions 群成员同步配置
+	ReconcileOptions struct {
+		BatchSize    int           // （选填）单次添加或删除的成员数量，默认为100
+		Interval     time.Duration // （选填）相邻两次接口调用的最小间隔，默认为100毫秒
+		KeepUnlisted bool          // （选填）是否保留期望成员之外的现有成员，默认删除
+		Silence      bool          // （选填）是否静默添加或删除成员
+		Reason       string        // （选填）删除成员的原因
+		DryRun       bool          // （选填）是否仅生成同步报告而不实际调用接口
+	}
+
+	// ReconcileChange 单个成员的同步变更
+	ReconcileChange struct {
+		Action  ReconcileAction // 同步操作
+		UserId  string          // 成员ID
+		Fields  []string        // 需修改的字段，自定义字段以 AppMemberDefinedData.{Key} 表示
+		Member  *Member         // 期望的成员资料
+		Applied bool            // 是否已执行成功
+		Err     error           // 执行失败的错误
+	}
+
+	// ReconcilePlan 群成员同步计划
+	ReconcilePlan struct {
+		GroupId   string             // 群ID
+		Changes   []*ReconcileChange // 变更列表，按添加、转让群主、修改、删除的顺序执行
+		Unchanged int                // 无需变更的成员数
+	}
+
+	// ReconcileReport 群成员同步报告
+	ReconcileReport struct {
+		Plan    *ReconcilePlan // 同步计划
+		DryRun  bool           // 是否为演练
+		Added   int            // 添加成功的成员数
+		Removed int            // 删除成功的成员数
+		Updated int            // 修改成功的成员数
+		Failed  int            // 执行失败的变更数
+	}
+
+	// Reconciler 群成员同步器
+	// 以期望的成员集合（成员ID、群内身份、群名片、自定义字段）为准，对比现有群成员生成变更计划并分批执行。
+	// 期望成员的群内身份及群名片为空时表示不修改，自定义字段仅对比期望成员中设置的字段。
+	Reconciler struct {
+		api  API
+		opt  ReconcileOptions
+		last time.Time
+	}
+)
+
+// NewReconciler 新建群成员同步器
+func NewReconciler(api API, opt ...ReconcileOptions) *Reconciler {
+	o := ReconcileOptions{}
+	if len(opt) > 0 {
+		o = opt[0]
+	}
+	if o.BatchSize <= 0 {
+		o.BatchSize = defaultReconcileBatchSize
+	}
+	if o.Interval < 0 {
+		o.Interval = 0
+	} else if o.Interval == 0 {
+		o.Interval = defaultReconcileInterval
+	}
+
+	return &Reconciler{api: api, opt: o}
+}
+
+// Reconcile 同步群成员，依次生成并执行同步计划
+func (r *Reconciler) Reconcile(ctx context.Context, groupId string, desired []*Member) (report *ReconcileReport, err error) {
+	plan, err := r.Plan(ctx, groupId, desired)
+	if err != nil {
+		return
+	}
+
+	return r.Apply(ctx, plan)
+}
+
+// Plan 拉取现有群成员并生成同步计划
+func (r *Reconciler) Plan(ctx context.Context, groupId string, desired []*Member) (plan *ReconcilePlan, err error) {
+	var (
+		wanted = make(map[string]*Member, len(desired))
+		owner  string
+		filter = &Filter{}
+	)
+
+	for _, member := range desired {
+		if err = member.checkError(); err != nil {
+			return
+		}
+		if _, ok := wanted[member.userId]; ok {
+			err = errReconcileDuplicateMember
+			return
+		}
+		if member.role == MemberRoleOwner {
+			if owner != "" {
+				err = errReconcileMultipleOwners
+				return
+			}
+			owner = member.userId
+		}
+		wanted[member.userId] = member
+
+		for key := range member.customData {
+			filter.AddMemberCustomDataFilter(key)
+		}
+	}
+
+	filter.AddMemberInfoFilter(MemberFieldUserId)
+	filter.AddMemberInfoFilter(MemberFieldRole)
+	filter.AddMemberInfoFilter(MemberFieldNameCard)
+
+	live := make(map[string]*Member)
+	for member, e := range r.api.IterMembers(&PullMembersArg{GroupId: groupId, Filter: filter}).All(ctx) {
+		if e != nil {
+			err = e
+			return
+		}
+		live[member.userId] = member
+	}
+
+	plan = &ReconcilePlan{GroupId: groupId}
+
+	var adds, transfers, updates, removes []*ReconcileChange
+
+	// 转让群主后原群主成为普通成员，可继续按期望修改或删除
+	if owner != "" {
+		if current, ok := live[owner]; !ok || current.role != MemberRoleOwner {
+			transfers = append(transfers, &ReconcileChange{Action: ReconcileActionTransfer, UserId: owner, Member: wanted[owner]})
+			for _, member := range live {
+				if member.role == MemberRoleOwner {
+					member.role = MemberRoleMember
+				}
+			}
+		}
+	}
+
+	for _, userId := range sortedKeys(wanted) {
+		member := wanted[userId]
+		current, ok := live[userId]
+		if !ok {
+			adds = append(adds, &ReconcileChange{Action: ReconcileActionAdd, UserId: userId, Fields: diffMember(nil, member), Member: member})
+			continue
+		}
+
+		if fields := diffMember(current, member); len(fields) > 0 {
+			updates = append(updates, &ReconcileChange{Action: ReconcileActionUpdate, UserId: userId, Fields: fields, Member: member})
+		} else {
+			plan.Unchanged++
+		}
+	}
+
+	if !r.opt.KeepUnlisted {
+		for _, userId := range sortedKeys(live) {
+			if _, ok := wanted[userId]; !ok && live[userId].role != MemberRoleOwner {
+				removes = append(removes, &ReconcileChange{Action: ReconcileActionRemove, UserId: userId})
+			}
+		}
+	}
+
+	plan.Changes = append(plan.Changes, adds...)
+	plan.Changes = append(plan.Changes, transfers...)
+	plan.Changes = append(plan.Changes, updates...)
+	plan.Changes = append(plan.Changes, removes...)
+
+	return
+}
+
+// Apply 执行同步计划
+// 添加及删除按批次执行，相邻两次接口调用之间至少间隔 Interval；单个变更失败时记录错误并继续执行其余变更。
+// 上下文取消时立即返回已执行部分的报告及上下文错误。
+func (r *Reconciler) Apply(ctx context.Context, plan *ReconcilePlan) (report *ReconcileReport, err error) {
+	report = &ReconcileReport{Plan: plan, DryRun: r.opt.DryRun}
+	if r.opt.DryRun {
+		return
+	}
+
+	groups := make(map[ReconcileAction][]*ReconcileChange)
+	for _, change := range plan.Changes {
+		groups[change.Action] = append(groups[change.Action], change)
+	}
+
+	for _, batch := range batches(groups[ReconcileActionAdd], r.opt.BatchSize) {
+		if err = r.wait(ctx); err != nil {
+			return
+		}
+		if err = r.add(ctx, report, plan.GroupId, batch); err != nil {
+			return
+		}
+	}
+
+	for _, change := range groups[ReconcileActionTransfer] {
+		if err = r.wait(ctx); err != nil {
+			return
+		}
+		r.finish(report, change, r.api.ChangeGroupOwner(plan.GroupId, change.UserId))
+	}
+
+	for _, change := range groups[ReconcileActionUpdate] {
+		if err = r.wait(ctx); err != nil {
+			return
+		}
+		r.finish(report, change, r.api.UpdateMember(plan.GroupId, updateMember(change)))
+	}
+
+	for _, batch := range batches(groups[ReconcileActionRemove], r.opt.BatchSize) {
+		if err = r.wait(ctx); err != nil {
+			return
+		}
+
+		userIds := make([]string, 0, len(batch))
+		for _, change := range batch {
+			userIds = append(userIds, change.UserId)
+		}
+
+		e := r.api.DeleteMembers(plan.GroupId, userIds, r.opt.Reason, r.opt.Silence)
+		for _, change := range batch {
+			r.finish(report, change, e)
+		}
+	}
+
+	return
+}
+
+// add 批量添加成员，并为设置了群内身份、群名片或自定义字段的新成员修改资料
+func (r *Reconciler) add(ctx context.Context, report *ReconcileReport, groupId string, batch []*ReconcileChange) (err error) {
+	userIds := make([]string, 0, len(batch))
+	for _, change := range batch {
+		userIds = append(userIds, change.UserId)
+	}
+
+	results, e := r.api.AddMembers(groupId, userIds, r.opt.Silence)
+	if e != nil {
+		for _, change := range batch {
+			r.finish(report, change, e)
+		}
+		return
+	}
+
+	failed := make(map[string]bool)
+	for _, result := range results {
+		if result.Result == addMemberResultFailed {
+			failed[result.UserId] = true
+		}
+	}
+
+	for _, change := range batch {
+		if failed[change.UserId] {
+			r.finish(report, change, errReconcileAddMemberFailed)
+			continue
+		}
+
+		if len(change.Fields) > 0 {
+			if err = r.wait(ctx); err != nil {
+				return
+			}
+			if e = r.api.UpdateMember(groupId, updateMember(change)); e != nil {
+				r.finish(report, change, e)
+				continue
+			}
+		}
+
+		r.finish(report, change, nil)
+	}
+
+	return
+}
+
+// finish 记录变更的执行结果
+func (r *Reconciler) finish(report *ReconcileReport, change *ReconcileChange, err error) {
+	if err != nil {
+		change.Err = err
+		report.Failed++
+		return
+	}
+
+	change.Applied = true
+
+	switch change.Action {
+	case ReconcileActionAdd:
+		report.Added++
+	case ReconcileActionRemove:
+		report.Removed++
+	case ReconcileActionUpdate, ReconcileActionTransfer:
+		report.Updated++
+	}
+}
+
+// wait 等待至距上次接口调用满足最小间隔
+func (r *Reconciler) wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if d := r.opt.Interval - time.Since(r.last); !r.last.IsZero() && d > 0 {
+		timer := time.NewTimer(d)
+		defer timer.Stop()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	r.last = time.Now()
+
+	return nil
+}
+
+// String 输出同步报告摘要
+func (r *ReconcileReport) String() string {
+	counts := make(map[ReconcileAction]int)
+	for _, change := range r.Plan.Changes {
+		counts[change.Action]++
+	}
+
+	mode := "apply"
+	if r.DryRun {
+		mode = "dry-run"
+	}
+
+	return fmt.Sprintf("%s %s: %d to add, %d to update, %d to remove, %d owner transfer, %d unchanged; %d added, %d updated, %d removed, %d failed",
+		mode, r.Plan.GroupId, counts[ReconcileActionAdd], counts[ReconcileActionUpdate], counts[ReconcileActionRemove], counts[ReconcileActionTransfer],
+		r.Plan.Unchanged, r.Added, r.Updated, r.Removed, r.Failed)
+}
+
+// diffMember 对比现有成员与期望成员，返回需修改的字段
+// 现有成员为 nil 时返回新成员入群后需设置的字段
+func diffMember(current, desired *Member) (fields []string) {
+	if current == nil {
+		current = &Member{role: MemberRoleMember}
+	}
+
+	if desired.role != "" && desired.role != MemberRoleOwner && desired.role != current.role {
+		fields = append(fields, string(MemberFieldRole))
+	}
+
+	if desired.nameCard != "" && desired.nameCard != current.nameCard {
+		fields = append(fields, string(MemberFieldNameCard))
+	}
+
+	// 服务端返回的自定义数据均为字符串，非字符串的期望值按 JSON 编码后比较
+	for _, key := range sortedKeys(desired.customData) {
+		if val, ok := current.GetCustomData(key); !ok || customDataString(val) != customDataString(desired.customData[key]) {
+			fields = append(fields, reconcileCustomFieldPrefix+key)
+		}
+	}
+
+	return
+}
+
+// updateMember 生成仅包含需修改字段的成员资料
+func updateMember(change *ReconcileChange) *Member {
+	member := NewMember(change.UserId)
+
+	for _, field := range change.Fields {
+		switch field {
+		case string(MemberFieldRole):
+			member.role = change.Member.role
+		case string(MemberFieldNameCard):
+			member.nameCard = change.Member.nameCard
+		default:
+			key := strings.TrimPrefix(field, reconcileCustomFieldPrefix)
+			member.SetCustomData(key, customDataString(change.Member.customData[key]))
+		}
+	}
+
+	return member
+}
+
+// batches 按批次大小切分变更列表
+func batches(changes []*ReconcileChange, size int) (ret [][]*ReconcileChange) {
+	for len(changes) > size {
+		ret = append(ret, changes[:size])
+		changes = changes[size:]
+	}
+
+	if len(changes) > 0 {
+		ret = append(ret, changes)
+	}
+
+	return
+}
+
+// sortedKeys 获取排序后的键列表，保证同步计划的顺序稳定
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,149 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 声明式群成员同步单元测试
+ */
+
+package group
+
+import (
+	"context"
+	"reflect"
+	"testing"
+)
+
+func reconcileClient() *fakeClient {
+	return newFakeClient(map[string]func(map[string]interface{}) string{
+		commandFetchGroupMembers: reply(`{"ActionStatus":"OK","MemberNum":4,"MemberList":[
+			{"Member_Account":"o","Role":"Owner"},
+			{"Member_Account":"a","Role":"Admin","NameCard":"A"},
+			{"Member_Account":"m","Role":"Member","AppMemberDefinedData":[{"Key":"level","Value":"1"}]},
+			{"Member_Account":"x","Role":"Member"}
+		]}`),
+		commandAddGroupMembers:       reply(`{"ActionStatus":"OK","MemberList":[{"Member_Account":"n","Result":1}]}`),
+		commandModifyGroupMemberInfo: reply(`{"ActionStatus":"OK"}`),
+		commandDeleteGroupMember:     reply(`{"ActionStatus":"OK"}`),
+	})
+}
+
+func desiredMembers() []*Member {
+	owner := NewMember("o")
+	owner.SetRole(MemberRoleOwner)
+
+	admin := NewMember("a")
+	admin.SetRole(MemberRoleMember)
+	admin.SetNameCard("A")
+
+	member := NewMember("m")
+	member.SetCustomData("level", "2")
+
+	newcomer := NewMember("n")
+	newcomer.SetRole(MemberRoleAdmin)
+	newcomer.SetNameCard("N")
+
+	return []*Member{owner, admin, member, newcomer}
+}
+
+func TestReconciler_Plan(t *testing.T) {
+	client := reconcileClient()
+	report, err := NewReconciler(NewAPI(client), ReconcileOptions{DryRun: true}).Reconcile(context.Background(), "g1", desiredMembers())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type change struct {
+		Action ReconcileAction
+		UserId string
+		Fields []string
+	}
+
+	var got []change
+	for _, c := range report.Plan.Changes {
+		got = append(got, change{c.Action, c.UserId, c.Fields})
+	}
+
+	want := []change{
+		{ReconcileActionAdd, "n", []string{"Role", "NameCard"}},
+		{ReconcileActionUpdate, "a", []string{"Role"}},
+		{ReconcileActionUpdate, "m", []string{"AppMemberDefinedData.level"}},
+		{ReconcileActionRemove, "x", nil},
+	}
+	if !reflect.DeepEqual(got, want) || report.Plan.Unchanged != 1 {
+		t.Fatalf("unexpected plan: %+v, unchanged %d", got, report.Plan.Unchanged)
+	}
+
+	if len(client.requests) != 1 || !report.DryRun || report.Added != 0 {
+		t.Fatalf("dry run should not apply changes: %d requests, %+v", len(client.requests), report)
+	}
+	if filter := client.requests[0].Body["AppDefinedDataFilter_GroupMember"]; !reflect.DeepEqual(filter, []interface{}{"level"}) {
+		t.Fatalf("custom data filter not set: %v", client.requests[0].Body)
+	}
+}
+
+func TestReconciler_NonStringCustomData(t *testing.T) {
+	member := NewMember("m")
+	member.SetCustomData("level", 1)
+
+	report, err := NewReconciler(NewAPI(reconcileClient()), ReconcileOptions{DryRun: true}).Reconcile(context.Background(), "g1", []*Member{member})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, c := range report.Plan.Changes {
+		if c.UserId == "m" {
+			t.Fatalf("non-string custom data equal to the live value reported as drift: %+v", c)
+		}
+	}
+
+	member.SetCustomData("level", 2)
+	if fields := diffMember(NewMember("m"), member); !reflect.DeepEqual(fields, []string{"AppMemberDefinedData.level"}) {
+		t.Fatalf("unexpected drift: %v", fields)
+	}
+	if val, _ := updateMember(&ReconcileChange{UserId: "m", Member: member, Fields: []string{"AppMemberDefinedData.level"}}).GetCustomData("level"); val != "2" {
+		t.Fatalf("custom data should be sent as a string, got %#v", val)
+	}
+}
+
+func TestReconciler_Apply(t *testing.T) {
+	client := reconcileClient()
+	report, err := NewReconciler(NewAPI(client), ReconcileOptions{Interval: -1, BatchSize: 1}).Reconcile(context.Background(), "g1", desiredMembers())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Added != 1 || report.Updated != 2 || report.Removed != 1 || report.Failed != 0 {
+		t.Fatalf("unexpected report: %s", report)
+	}
+
+	var commands []string
+	for _, req := range client.requests {
+		commands = append(commands, req.Command)
+	}
+
+	want := []string{
+		commandFetchGroupMembers,
+		commandAddGroupMembers,
+		commandModifyGroupMemberInfo,
+		commandModifyGroupMemberInfo,
+		commandModifyGroupMemberInfo,
+		commandDeleteGroupMember,
+	}
+	if !reflect.DeepEqual(commands, want) {
+		t.Fatalf("unexpected commands: %v", commands)
+	}
+
+	if body := client.requests[2].Body; body["Member_Account"] != "n" || body["Role"] != "Admin" || body["NameCard"] != "N" {
+		t.Fatalf("unexpected newcomer update: %v", body)
+	}
+	if body := client.requests[3].Body; body["Member_Account"] != "a" || body["Role"] != "Member" || body["NameCard"] != nil {
+		t.Fatalf("unexpected admin update: %v", body)
+	}
+}
+
+func TestReconciler_ContextCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := NewReconciler(NewAPI(reconcileClient())).Reconcile(ctx, "g1", desiredMembers()); err != context.Canceled {
+		t.Fatalf("expected context canceled, got %v", err)
+	}
+}
@@ -0,0 +1,280 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 群组模板及声明式开通
+ */
+
+package group
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/d60-Lab/tencent-im/internal/core"
+	"github.com/d60-Lab/tencent-im/internal/enum"
+)
+
+const errCodeGroupIdOwned = 10025 // 群组 ID 已被使用，且操作者为群主
+
+var (
+	errNotSetTemplateName     = core.NewError(enum.InvalidParamsCode, "template name is not set")
+	errDuplicateTemplate      = core.NewError(enum.InvalidParamsCode, "duplicate template name")
+	errTemplateNotFound       = core.NewError(enum.InvalidParamsCode, "template not found")
+	errTemplateGroupIdNotSet  = core.NewError(enum.InvalidParamsCode, "provisioning requires a custom group id")
+	errTemplateTypeMismatched = core.NewError(enum.InvalidParamsCode, "existing group type does not match the template")
+)
+
+type (
+	// Template 群组模板
+	// GroupName、Introduction 及 Notification 中的 {key} 将在开通时替换为变量中对应的值
+	Template struct {
+		Name            string                 `json:"name" yaml:"name"`                                               // （必填）模板名称
+		Type            Type                   `json:"type" yaml:"type"`                                               // （必填）群类型
+		GroupName       string                 `json:"group_name" yaml:"group_name"`                                   // （必填）群名称，支持变量
+		Introduction    string                 `json:"introduction,omitempty" yaml:"introduction,omitempty"`           // （选填）群简介，支持变量
+		Notification    string                 `json:"notification,omitempty" yaml:"notification,omitempty"`           // （选填）群公告，支持变量
+		Avatar          string                 `json:"avatar,omitempty" yaml:"avatar,omitempty"`                       // （选填）群头像
+		Owner           string                 `json:"owner,omitempty" yaml:"owner,omitempty"`                         // （选填）群主ID，支持变量
+		MaxMemberNum    uint                   `json:"max_member_num,omitempty" yaml:"max_member_num,omitempty"`       // （选填）最大群成员数量
+		ApplyJoinOption ApplyJoinOption        `json:"apply_join_option,omitempty" yaml:"apply_join_option,omitempty"` // （选填）申请加群处理方式
+		SupportTopic    bool                   `json:"support_topic,omitempty" yaml:"support_topic,omitempty"`         // （选填）是否支持话题，仅社群有效
+		CustomData      map[string]interface{} `json:"custom_data,omitempty" yaml:"custom_data,omitempty"`             // （选填）群自定义数据
+		Admins          []string               `json:"admins,omitempty" yaml:"admins,omitempty"`                       // （选填）初始管理员ID，仅在创建群组时添加
+	}
+
+	// ProvisionResult 群组开通结果
+	ProvisionResult struct {
+		GroupId string   // 群ID
+		Created bool     // 是否新建了群组
+		Drift   []string // 已存在的群组与模板不一致并已修正的字段
+	}
+
+	// Provisioner 基于模板的群组开通器
+	Provisioner struct {
+		api       API
+		templates map[string]*Template
+	}
+)
+
+// ParseTemplates 解析群组模板列表
+// 默认按 JSON 格式解析，传入 yaml.Unmarshal 等解码函数即可解析 YAML 等格式
+func ParseTemplates(data []byte, unmarshal ...func([]byte, interface{}) error) (templates []*Template, err error) {
+	decode := json.Unmarshal
+	if len(unmarshal) > 0 && unmarshal[0] != nil {
+		decode = unmarshal[0]
+	}
+
+	if err = decode(data, &templates); err != nil {
+		return
+	}
+
+	names := make(map[string]bool, len(templates))
+	for _, t := range templates {
+		if t.Name == "" {
+			err = errNotSetTemplateName
+			return
+		}
+		if names[t.Name] {
+			err = errDuplicateTemplate
+			return
+		}
+		names[t.Name] = true
+	}
+
+	return
+}
+
+// LoadTemplates 从文件加载群组模板列表
+// 默认按 JSON 格式解析，传入 yaml.Unmarshal 等解码函数即可解析 YAML 等格式
+func LoadTemplates(path string, unmarshal ...func([]byte, interface{}) error) ([]*Template, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseTemplates(data, unmarshal...)
+}
+
+// Build 按模板及变量生成群组
+func (t *Template) Build(groupId string, vars map[string]string) *Group {
+	replacer := templateReplacer(vars)
+
+	group := NewGroup(groupId)
+	group.SetGroupType(t.Type)
+	group.SetName(replacer.Replace(t.GroupName))
+	group.SetIntroduction(replacer.Replace(t.Introduction))
+	group.SetNotification(replacer.Replace(t.Notification))
+	group.SetAvatar(t.Avatar)
+	group.SetOwner(replacer.Replace(t.Owner))
+	group.SetMaxMemberNum(t.MaxMemberNum)
+	group.SetApplyJoinOption(t.ApplyJoinOption)
+	group.SetSupportTopic(t.SupportTopic)
+
+	for key, val := range t.CustomData {
+		group.SetCustomData(key, customDataString(val))
+	}
+
+	for _, userId := range t.Admins {
+		member := NewMember(userId)
+		member.SetRole(MemberRoleAdmin)
+		group.AddMembers(member)
+	}
+
+	return group
+}
+
+// NewProvisioner 新建群组开通器
+func NewProvisioner(api API, templates ...*Template) *Provisioner {
+	p := &Provisioner{api: api, templates: make(map[string]*Template, len(templates))}
+	for _, t := range templates {
+		p.templates[t.Name] = t
+	}
+
+	return p
+}
+
+// AddTemplate 添加群组模板，同名模板将被覆盖
+func (p *Provisioner) AddTemplate(t *Template) {
+	p.templates[t.Name] = t
+}
+
+// GetTemplate 获取群组模板
+func (p *Provisioner) GetTemplate(name string) (t *Template, ok bool) {
+	t, ok = p.templates[name]
+	return
+}
+
+// Provision 按模板开通群组
+// 以自定义群ID幂等创建群组：群ID已被操作者自己使用（10025）时拉取现有群组，对比模板中的基础资料及自定义数据，并通过修改群基础资料接口修正不一致的字段。
+// 群ID已被他人使用（10021）时返回错误，不会修改他人的群组。
+// 初始管理员仅在创建时添加，已存在群组的成员可通过 Reconciler 同步。
+func (p *Provisioner) Provision(templateName, groupId string, vars map[string]string) (ret *ProvisionResult, err error) {
+	t, ok := p.templates[templateName]
+	if !ok {
+		err = errTemplateNotFound
+		return
+	}
+
+	if groupId == "" {
+		err = errTemplateGroupIdNotSet
+		return
+	}
+
+	desired := t.Build(groupId, vars)
+	ret = &ProvisionResult{GroupId: groupId}
+
+	if _, err = p.api.CreateGroup(desired); err == nil {
+		ret.Created = true
+		return
+	}
+
+	var e core.Error
+	if !errors.As(err, &e) || e.Code() != errCodeGroupIdOwned {
+		return
+	}
+
+	filter := &Filter{}
+	for _, field := range []BaseInfoField{BaseFieldType, BaseFieldName, BaseFieldIntroduction, BaseFieldNotification, BaseFieldAvatar, BaseFieldMaxMemberNum, BaseFieldApplyJoinOption} {
+		filter.AddBaseInfoFilter(field)
+	}
+	for key := range t.CustomData {
+		filter.AddGroupCustomDataFilter(key)
+	}
+
+	current, err := p.api.GetGroup(groupId, filter)
+	if err != nil {
+		return
+	}
+
+	if current.groupType != desired.groupType {
+		err = errTemplateTypeMismatched
+		return
+	}
+
+	update, drift := diffGroup(current, desired)
+	if ret.Drift = drift; len(drift) == 0 {
+		return
+	}
+
+	err = p.api.UpdateGroup(update)
+
+	return
+}
+
+// diffGroup 对比现有群组与期望群组，返回仅包含不一致字段的修改资料及不一致的字段列表
+func diffGroup(current, desired *Group) (update *Group, drift []string) {
+	update = NewGroup(desired.id)
+
+	if current.name != desired.name {
+		update.SetName(desired.name)
+		drift = append(drift, string(BaseFieldName))
+	}
+
+	if desired.introduction != "" && current.introduction != desired.introduction {
+		update.SetIntroduction(desired.introduction)
+		drift = append(drift, string(BaseFieldIntroduction))
+	}
+
+	if desired.notification != "" && current.notification != desired.notification {
+		update.SetNotification(desired.notification)
+		drift = append(drift, string(BaseFieldNotification))
+	}
+
+	if desired.avatar != "" && current.avatar != desired.avatar {
+		update.SetAvatar(desired.avatar)
+		drift = append(drift, string(BaseFieldAvatar))
+	}
+
+	if desired.maxMemberNum != 0 && current.maxMemberNum != desired.maxMemberNum {
+		update.SetMaxMemberNum(desired.maxMemberNum)
+		drift = append(drift, string(BaseFieldMaxMemberNum))
+	}
+
+	if desired.applyJoinOption != "" && current.applyJoinOption != desired.applyJoinOption {
+		update.SetApplyJoinOption(ApplyJoinOption(desired.applyJoinOption))
+		drift = append(drift, string(BaseFieldApplyJoinOption))
+	}
+
+	// 服务端以字符串保存群自定义数据，两侧均转换为字符串后比较
+	for _, key := range sortedKeys(desired.customData) {
+		want := customDataString(desired.customData[key])
+		if val, ok := current.GetCustomData(key); !ok || customDataString(val) != want {
+			update.SetCustomData(key, want)
+			drift = append(drift, "AppDefinedData."+key)
+		}
+	}
+
+	return
+}
+
+// customDataString 将群自定义数据转换为字符串，非字符串的值以 JSON 编码
+func customDataString(val interface{}) string {
+	switch v := val.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	}
+
+	b, err := json.Marshal(val)
+	if err != nil {
+		return fmt.Sprint(val)
+	}
+
+	return string(b)
+}
+
+// templateReplacer 生成模板变量替换器
+func templateReplacer(vars map[string]string) *strings.Replacer {
+	oldnew := make([]string, 0, len(vars)*2)
+	for key, val := range vars {
+		oldnew = append(oldnew, "{"+key+"}", val)
+	}
+
+	return strings.NewReplacer(oldnew...)
+}
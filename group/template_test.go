@@ -0,0 +1,127 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 群组模板单元测试
+ */
+
+package group
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/d60-Lab/tencent-im/internal/core"
+)
+
+const testTemplates = `[
+	{
+		"name": "team",
+		"type": "Public",
+		"group_name": "{team} team",
+		"introduction": "Chat for {team}",
+		"max_member_num": 500,
+		"apply_join_option": "NeedPermission",
+		"custom_data": {"dept": "rd", "level": 3, "public": true},
+		"admins": ["lead"]
+	}
+]`
+
+func TestParseTemplates(t *testing.T) {
+	templates, err := ParseTemplates([]byte(testTemplates))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g := templates[0].Build("team_go", map[string]string{"team": "Go"})
+	if g.GetName() != "Go team" || g.GetIntroduction() != "Chat for Go" || g.GetMaxMemberNum() != 500 || g.GetApplyJoinOption() != "NeedPermission" {
+		t.Fatalf("unexpected group: %+v", g)
+	}
+	if members := g.GetMembers(); len(members) != 1 || members[0].GetRole() != MemberRoleAdmin {
+		t.Fatalf("unexpected members: %+v", members)
+	}
+
+	if _, err = ParseTemplates([]byte(`[{"name":"a"},{"name":"a"}]`)); err != errDuplicateTemplate {
+		t.Fatalf("expected duplicate template error, got %v", err)
+	}
+}
+
+func TestProvisioner_Provision(t *testing.T) {
+	templates, _ := ParseTemplates([]byte(testTemplates))
+
+	client := newFakeClient(map[string]func(map[string]interface{}) string{
+		commandCreateGroup: reply(`{"ActionStatus":"OK","GroupId":"team_go"}`),
+	})
+	p := NewProvisioner(NewAPI(client), templates...)
+
+	ret, err := p.Provision("team", "team_go", map[string]string{"team": "Go"})
+	if err != nil || !ret.Created {
+		t.Fatalf("unexpected result: %+v, %v", ret, err)
+	}
+	if body := client.last().Body; body["GroupId"] != "team_go" || body["Name"] != "Go team" || body["MaxMemberCount"] != float64(500) {
+		t.Fatalf("unexpected create request: %v", body)
+	}
+
+	client = newFakeClient(map[string]func(map[string]interface{}) string{
+		commandCreateGroup: reply(`{"ActionStatus":"FAIL","ErrorCode":10025,"ErrorInfo":"group id has been used by yourself"}`),
+		commandGetGroups: reply(`{"ActionStatus":"OK","GroupInfo":[{
+			"GroupId":"team_go","Type":"Public","Name":"Go team","Introduction":"old",
+			"MaxMemberNum":500,"ApplyJoinOption":"NeedPermission",
+			"AppDefinedData":[{"Key":"dept","Value":"rd"},{"Key":"level","Value":"3"},{"Key":"public","Value":"true"}]
+		}]}`),
+		commandUpdateGroup: reply(`{"ActionStatus":"OK"}`),
+	})
+	p = NewProvisioner(NewAPI(client), templates...)
+
+	if ret, err = p.Provision("team", "team_go", map[string]string{"team": "Go"}); err != nil {
+		t.Fatal(err)
+	}
+	if ret.Created || !reflect.DeepEqual(ret.Drift, []string{"Introduction"}) {
+		t.Fatalf("unexpected result: %+v", ret)
+	}
+
+	body := client.last().Body
+	if client.last().Command != commandUpdateGroup || body["Introduction"] != "Chat for Go" || body["Name"] != nil || body["MaxMemberNum"] != nil || body["AppDefinedData"] != nil {
+		t.Fatalf("unexpected update request: %v", body)
+	}
+
+	// 再次开通时仅修正仍不一致的字段
+	client = newFakeClient(map[string]func(map[string]interface{}) string{
+		commandCreateGroup: reply(`{"ActionStatus":"FAIL","ErrorCode":10025,"ErrorInfo":"group id has been used by yourself"}`),
+		commandGetGroups: reply(`{"ActionStatus":"OK","GroupInfo":[{
+			"GroupId":"team_go","Type":"Public","Name":"Go team","Introduction":"Chat for Go",
+			"MaxMemberNum":500,"ApplyJoinOption":"NeedPermission",
+			"AppDefinedData":[{"Key":"dept","Value":"rd"},{"Key":"level","Value":"4"}]
+		}]}`),
+		commandUpdateGroup: reply(`{"ActionStatus":"OK"}`),
+	})
+	p = NewProvisioner(NewAPI(client), templates...)
+
+	if ret, err = p.Provision("team", "team_go", map[string]string{"team": "Go"}); err != nil {
+		t.Fatal(err)
+	}
+	if ret.Created || !reflect.DeepEqual(ret.Drift, []string{"AppDefinedData.level", "AppDefinedData.public"}) {
+		t.Fatalf("unexpected result: %+v", ret)
+	}
+	if body = client.last().Body; body["Name"] != nil || len(body["AppDefinedData"].([]interface{})) != 2 {
+		t.Fatalf("unexpected update request: %v", body)
+	}
+
+	// 群ID已被他人使用时返回错误，不修改现有群组
+	client = newFakeClient(map[string]func(map[string]interface{}) string{
+		commandCreateGroup: reply(`{"ActionStatus":"FAIL","ErrorCode":10021,"ErrorInfo":"group id has been used"}`),
+	})
+	p = NewProvisioner(NewAPI(client), templates...)
+
+	var e core.Error
+	if _, err = p.Provision("team", "team_go", map[string]string{"team": "Go"}); !errors.As(err, &e) || e.Code() != 10021 {
+		t.Fatalf("expected error 10021, got %v", err)
+	}
+	if client.last().Command != commandCreateGroup {
+		t.Fatalf("unexpected request after 10021: %v", client.last().Command)
+	}
+
+	if _, err = p.Provision("team", "", nil); err != errTemplateGroupIdNotSet {
+		t.Fatalf("expected group id error, got %v", err)
+	}
+}
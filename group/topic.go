@@ -0,0 +1,210 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 社群话题实体类
+ */
+
+package group
+
+import (
+	"strings"
+	"time"
+
+	"github.com/d60-Lab/tencent-im/internal/core"
+	"github.com/d60-Lab/tencent-im/internal/enum"
+)
+
+const (
+	communityGroupIdPrefix = "@TGS#_"   // 社群自定义群ID前缀
+	topicIdSeparator       = "@TOPIC#_" // 话题ID中群ID与自定义部分的分隔符
+)
+
+var (
+	errNotSetTopicName          = core.NewError(enum.InvalidParamsCode, "topic name is not set")
+	errNotSetTopicId            = core.NewError(enum.InvalidParamsCode, "topic id is not set")
+	errTopicNameTooLong         = core.NewError(enum.InvalidParamsCode, "topic name is too long")
+	errTopicIntroductionTooLong = core.NewError(enum.InvalidParamsCode, "topic introduction is too long")
+	errTopicNotificationTooLong = core.NewError(enum.InvalidParamsCode, "topic notification is too long")
+	errInvalidTopicId           = core.NewError(enum.InvalidParamsCode, "topic id must be in the format of {GroupId}@TOPIC#_{custom}")
+	errInvalidCommunityGroupId  = core.NewError(enum.InvalidParamsCode, "community group id must start with @TGS#_")
+	errTopicNotSupported        = core.NewError(enum.InvalidParamsCode, "only community groups support topics")
+)
+
+type Topic struct {
+	err          error
+	id           string // 话题ID
+	name         string // 话题名称
+	owner        string // 话题创建者ID
+	avatar       string // 话题头像
+	introduction string // 话题简介
+	notification string // 话题公告
+	customString string // 话题自定义字段
+	shutUpStatus string // 话题全员禁言状态
+	createTime   int64  // 话题创建时间
+	lastMsgTime  int64  // 话题内最后一条消息的时间
+	nextMsgSeq   int    // 话题内下一条消息的Seq
+}
+
+func NewTopic(id ...string) *Topic {
+	topic := &Topic{}
+	if len(id) > 0 {
+		topic.SetTopicId(id[0])
+	}
+	return topic
+}
+
+// TopicId 以群ID及自定义部分拼接话题ID
+func TopicId(groupId, custom string) string {
+	return groupId + topicIdSeparator + custom
+}
+
+// SetTopicId 设置话题ID，自定义话题ID须为 {GroupId}@TOPIC#_{custom} 格式
+func (t *Topic) SetTopicId(id string) {
+	t.id = id
+}
+
+// GetTopicId 获取话题ID
+func (t *Topic) GetTopicId() string {
+	return t.id
+}
+
+// SetOwner 设置话题创建者ID
+func (t *Topic) SetOwner(owner string) {
+	t.owner = owner
+}
+
+// GetOwner 获取话题创建者ID
+func (t *Topic) GetOwner() string {
+	return t.owner
+}
+
+// SetName 设置话题名称
+func (t *Topic) SetName(name string) {
+	t.name = name
+}
+
+// GetName 获取话题名称
+func (t *Topic) GetName() string {
+	return t.name
+}
+
+// SetAvatar 设置话题头像
+func (t *Topic) SetAvatar(avatar string) {
+	t.avatar = avatar
+}
+
+// GetAvatar 获取话题头像
+func (t *Topic) GetAvatar() string {
+	return t.avatar
+}
+
+// SetIntroduction 设置话题简介
+func (t *Topic) SetIntroduction(introduction string) {
+	t.introduction = introduction
+}
+
+// GetIntroduction 获取话题简介
+func (t *Topic) GetIntroduction() string {
+	return t.introduction
+}
+
+// SetNotification 设置话题公告
+func (t *Topic) SetNotification(notification string) {
+	t.notification = notification
+}
+
+// GetNotification 获取话题公告
+func (t *Topic) GetNotification() string {
+	return t.notification
+}
+
+// SetCustomString 设置话题自定义字段
+func (t *Topic) SetCustomString(customString string) {
+	t.customString = customString
+}
+
+// GetCustomString 获取话题自定义字段
+func (t *Topic) GetCustomString() string {
+	return t.customString
+}
+
+// SetShutUpStatus 设置话题全员禁言状态
+func (t *Topic) SetShutUpStatus(shutUpStatus ShutUpStatus) {
+	t.shutUpStatus = string(shutUpStatus)
+}
+
+// GetShutUpStatus 获取话题全员禁言状态
+func (t *Topic) GetShutUpStatus() string {
+	return t.shutUpStatus
+}
+
+// GetCreateTime 获取话题创建时间
+func (t *Topic) GetCreateTime() time.Time {
+	return time.Unix(t.createTime, 0)
+}
+
+// GetLastMsgTime 获取话题内最后一条消息的时间
+func (t *Topic) GetLastMsgTime() time.Time {
+	return time.Unix(t.lastMsgTime, 0)
+}
+
+// GetNextMsgSeq 获取话题内下一条消息的Seq
+func (t *Topic) GetNextMsgSeq() int {
+	return t.nextMsgSeq
+}
+
+// IsValid 检测话题是否有效
+func (t *Topic) IsValid() bool {
+	return t.err == nil
+}
+
+// GetError 获取异常错误
+func (t *Topic) GetError() error {
+	return t.err
+}
+
+// 设置异常错误
+func (t *Topic) setError(code int, message string) {
+	if code != enum.SuccessCode {
+		t.err = core.NewError(code, message)
+	}
+}
+
+// 检测创建错误
+func (t *Topic) checkCreateError(groupId string) (err error) {
+	if t.id != "" && !strings.HasPrefix(t.id, groupId+topicIdSeparator) {
+		return errInvalidTopicId
+	}
+
+	if t.name == "" {
+		return errNotSetTopicName
+	}
+
+	return t.checkLengthArgError()
+}
+
+// 检测更新错误
+func (t *Topic) checkUpdateError() (err error) {
+	if t.id == "" {
+		return errNotSetTopicId
+	}
+
+	return t.checkLengthArgError()
+}
+
+// 检测话题名称、简介及公告长度
+func (t *Topic) checkLengthArgError() error {
+	if len(t.name) > 150 {
+		return errTopicNameTooLong
+	}
+
+	if len(t.introduction) > 400 {
+		return errTopicIntroductionTooLong
+	}
+
+	if len(t.notification) > 400 {
+		return errTopicNotificationTooLong
+	}
+
+	return nil
+}
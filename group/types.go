@@ -72,6 +72,7 @@ type (
 		ApplyJoinOption string            `json:"ApplyJoinOption,omitempty"` // （选填）申请加群处理方式。包含 FreeAccess（自由加入），NeedPermission（需要验证），DisableApply（禁止加群），不填默认为 NeedPermission（需要验证） 仅当创建支持申请加群的 群组 时，该字段有效
 		AppDefinedData  []*customDataItem `json:"AppDefinedData,omitempty"`  // （选填）群组维度的自定义字段，默认情况是没有的，可以通过 即时通信 IM 控制台 进行配置，详情请参阅 自定义字段
 		MemberList      []*memberItem     `json:"MemberList,omitempty"`      // （选填）初始群成员列表，最多100个；成员信息字段详情请参阅 群成员资料
+		SupportTopic    int               `json:"SupportTopic,omitempty"`    // （选填）是否支持话题，1表示支持，仅社群（Community）有效
 	}
 
 	// 创建群（响应）
@@ -142,6 +143,7 @@ type (
 		AppDefinedData  []customDataItem `json:"AppDefinedData"`
 		MemberList      []memberItem     `json:"MemberList"`
 		MemberInfo      *memberItem      `json:"SelfInfo,omitempty"` // 成员在群中的信息（仅在获取用户所加入的群组接口返回）
+		SupportTopic    int              `json:"SupportTopic"`       // 是否支持话题
 	}
 
 	// 获取群成员详细资料（请求）
@@ -318,17 +320,20 @@ type (
 
 	// 在群组中发送普通消息（请求）
 	sendMessageReq struct {
-		GroupId               string                 `json:"GroupId"`                         // （必填）向哪个群组发送消息
-		Random                uint32                 `json:"Random"`                          // （必填）无符号32位整数
-		MsgPriority           string                 `json:"MsgPriority,omitempty"`           // （选填）消息的优先级
-		FromUserId            string                 `json:"From_Account,omitempty"`          // （选填）消息来源帐号
-		MsgBody               []*types.MsgBody       `json:"MsgBody"`                         // （必填）消息体
-		OnlineOnlyFlag        int                    `json:"MsgOnlineOnlyFlag,omitempty"`     // （选填）1表示消息仅发送在线成员，默认0表示发送所有成员，AVChatRoom(直播群)不支持该参数
-		SendMsgControl        []string               `json:"SendMsgControl,omitempty"`        // （选填）消息发送权限，NoLastMsg 只对单条消息有效，表示不更新最近联系人会话；NoUnread 不计未读，只对单条消息有效。（如果该消息 MsgOnlineOnlyFlag 设置为1，则不允许使用该字段。）
-		ForbidCallbackControl []string               `json:"ForbidCallbackControl,omitempty"` // （选填）消息回调禁止开关，只对单条消息有效
-		OfflinePushInfo       *types.OfflinePushInfo `json:"OfflinePushInfo,omitempty"`       // （选填）离线推送信息配置
-		CloudCustomData       string                 `json:"CloudCustomData,omitempty"`       // （选填）消息自定义数据（云端保存，会发送到对端，程序卸载重装后还能拉取到）
-		GroupAtInfo           []atInfo               `json:"GroupAtInfo,omitempty"`           // （选填）@某个用户或者所有人
+		GroupId               string                 `json:"GroupId"`                           // （必填）向哪个群组发送消息
+		TopicId               string                 `json:"TopicId,omitempty"`                 // （选填）向社群的哪个话题发送消息
+		Random                uint32                 `json:"Random"`                            // （必填）无符号32位整数
+		MsgPriority           string                 `json:"MsgPriority,omitempty"`             // （选填）消息的优先级
+		FromUserId            string                 `json:"From_Account,omitempty"`            // （选填）消息来源帐号
+		MsgBody               []*types.MsgBody       `json:"MsgBody"`                           // （必填）消息体
+		OnlineOnlyFlag        int                    `json:"MsgOnlineOnlyFlag,omitempty"`       // （选填）1表示消息仅发送在线成员，默认0表示发送所有成员，AVChatRoom(直播群)不支持该参数
+		SendMsgControl        []string               `json:"SendMsgControl,omitempty"`          // （选填）消息发送权限，NoLastMsg 只对单条消息有效，表示不更新最近联系人会话；NoUnread 不计未读，只对单条消息有效。（如果该消息 MsgOnlineOnlyFlag 设置为1，则不允许使用该字段。）
+		ForbidCallbackControl []string               `json:"ForbidCallbackControl,omitempty"`   // （选填）消息回调禁止开关，只对单条消息有效
+		OfflinePushInfo       *types.OfflinePushInfo `json:"OfflinePushInfo,omitempty"`         // （选填）离线推送信息配置
+		CloudCustomData       string                 `json:"CloudCustomData,omitempty"`         // （选填）消息自定义数据（云端保存，会发送到对端，程序卸载重装后还能拉取到）
+		GroupAtInfo           []atInfo               `json:"GroupAtInfo,omitempty"`             // （选填）@某个用户或者所有人
+		NeedReadReceipt       int                    `json:"NeedReadReceipt,omitempty"`         // （选填）1表示消息需要已读回执
+		SupportExtension      int                    `json:"SupportMessageExtension,omitempty"` // （选填）1表示消息支持消息扩展
 	}
 
 	// 在群组中发送普通消息（响应）
@@ -368,8 +373,9 @@ type (
 
 	// 撤销消息（请求）
 	revokeMessagesReq struct {
-		GroupId    string       `json:"GroupId"`    // （必填）操作的群ID
-		MsgSeqList []msgSeqItem `json:"MsgSeqList"` // （必填）被撤回的消息 seq 列表
+		GroupId    string       `json:"GroupId"`           // （必填）操作的群ID
+		TopicId    string       `json:"TopicId,omitempty"` // （选填）操作的话题ID，仅社群话题消息有效
+		MsgSeqList []msgSeqItem `json:"MsgSeqList"`        // （必填）被撤回的消息 seq 列表
 	}
 
 	// 撤销消息（响应）
@@ -466,6 +472,7 @@ type (
 	// 拉取群历史消息（请求）
 	fetchMessagesReq struct {
 		GroupId      string `json:"GroupId"`                // （必填）要拉取历史消息的群组 ID
+		TopicId      string `json:"TopicId,omitempty"`      // （选填）要拉取历史消息的话题 ID，仅社群话题有效
 		ReqMsgSeq    int    `json:"ReqMsgSeq"`              // （选填）拉取消息的最大seq
 		ReqMsgNumber int    `json:"ReqMsgNumber,omitempty"` // （必填）拉取的历史消息的条数，目前一次请求最多返回20条历史消息，所以这里最好小于等于20
 	}
@@ -485,14 +492,21 @@ type (
 		List       []*Message // 列表
 	}
 
+	// MessagesCursor 迭代群历史消息的分页游标
+	MessagesCursor struct {
+		GroupId string `json:"group_id"` // 群ID，首页为空
+		MsgSeq  int    `json:"msg_seq"`  // 待拉取的消息 Seq，0 表示从最新消息开始
+	}
+
 	rspMsgItem struct {
-		FromUserId   string          `json:"From_Account"`
-		IsPlaceMsg   int             `json:"IsPlaceMsg"`
-		MsgBody      []types.MsgBody `json:"MsgBody"`
-		MsgPriority  int             `json:"MsgPriority"`
-		MsgRandom    uint32          `json:"MsgRandom"`
-		MsgSeq       int             `json:"MsgSeq"`
-		MsgTimeStamp int64           `json:"MsgTimeStamp"`
+		FromUserId      string          `json:"From_Account"`
+		IsPlaceMsg      int             `json:"IsPlaceMsg"`
+		MsgBody         []types.MsgBody `json:"MsgBody"`
+		MsgPriority     int             `json:"MsgPriority"`
+		MsgRandom       uint32          `json:"MsgRandom"`
+		MsgSeq          int             `json:"MsgSeq"`
+		MsgTimeStamp    int64           `json:"MsgTimeStamp"`
+		CloudCustomData string          `json:"CloudCustomData"`
 	}
 
 	// 获取直播群在线人数（请求）
@@ -573,16 +587,12 @@ type (
 		AttrKeys []string `json:"AttrKeys"` // （必填）属性key列表
 	}
 
-	// 封禁群成员项
-	banMemberItem struct {
-		UserId     string `json:"Member_Account"` // 群成员ID
-		BanSeconds int64  `json:"BanSeconds"`     // 封禁时长，单位秒
-	}
-
 	// 封禁群成员（请求）
 	banGroupMemberReq struct {
-		GroupId string          `json:"GroupId"` // （必填）群ID
-		Members []banMemberItem `json:"Members"` // （必填）封禁成员列表
+		GroupId     string   `json:"GroupId"`               // （必填）群ID
+		UserIds     []string `json:"Members_Account"`       // （必填）封禁的成员ID列表
+		Duration    int64    `json:"Duration"`              // （必填）封禁时长，单位秒
+		Description string   `json:"Description,omitempty"` // （选填）封禁原因
 	}
 
 	// 解封群成员（请求）
@@ -615,4 +625,242 @@ type (
 		MsgSeq  int              `json:"MsgSeq"`  // （必填）消息seq
 		Message []*types.MsgBody `json:"MsgBody"` // （必填）消息体
 	}
+
+	// 创建话题（请求）
+	createTopicReq struct {
+		GroupId      string `json:"GroupId"`                // （必填）话题所属的社群ID
+		TopicId      string `json:"TopicId,omitempty"`      // （选填）自定义话题ID，格式为 {GroupId}@TOPIC#_{custom}
+		FromUserId   string `json:"From_Account,omitempty"` // （选填）话题创建者ID
+		TopicName    string `json:"TopicName"`              // （必填）话题名称
+		FaceUrl      string `json:"FaceUrl,omitempty"`      // （选填）话题头像 URL
+		Introduction string `json:"Introduction,omitempty"` // （选填）话题简介
+		Notification string `json:"Notification,omitempty"` // （选填）话题公告
+		CustomString string `json:"CustomString,omitempty"` // （选填）话题自定义字段
+	}
+
+	// 创建话题（响应）
+	createTopicResp struct {
+		types.ActionBaseResp
+		TopicId string `json:"TopicId"` // 话题ID
+	}
+
+	// 解散话题（请求）
+	destroyTopicReq struct {
+		GroupId     string   `json:"GroupId"`     // （必填）话题所属的社群ID
+		TopicIdList []string `json:"TopicIdList"` // （必填）待解散的话题ID列表
+	}
+
+	// 解散话题（响应）
+	destroyTopicResp struct {
+		types.ActionBaseResp
+		Results []destroyTopicResult `json:"DestroyResultItem"` // 解散结果列表
+	}
+
+	// 解散话题结果
+	destroyTopicResult struct {
+		TopicId   string `json:"TopicId"`   // 话题ID
+		ErrorCode int    `json:"ErrorCode"` // 解散结果：0表示成功；其它表示失败
+		ErrorInfo string `json:"ErrorInfo"` // 错误信息
+	}
+
+	// 获取话题资料（请求）
+	getTopicsReq struct {
+		GroupId     string   `json:"GroupId"`               // （必填）话题所属的社群ID
+		TopicIdList []string `json:"TopicIdList,omitempty"` // （选填）话题ID列表，不填则获取全部话题
+	}
+
+	// 获取话题资料（响应）
+	getTopicsResp struct {
+		types.ActionBaseResp
+		TopicInfos []*topicInfo `json:"TopicInfo"`
+	}
+
+	// 话题资料
+	topicInfo struct {
+		TopicId       string `json:"TopicId"`
+		ErrorCode     int    `json:"ErrorCode"`
+		ErrorInfo     string `json:"ErrorInfo"`
+		TopicName     string `json:"TopicName"`
+		FaceUrl       string `json:"FaceUrl"`
+		Introduction  string `json:"Introduction"`
+		Notification  string `json:"Notification"`
+		CustomString  string `json:"CustomString"`
+		OwnerUserId   string `json:"From_Account"`
+		CreateTime    int64  `json:"CreateTime"`
+		LastMsgTime   int64  `json:"LastMsgTime"`
+		NextMsgSeq    int    `json:"NextMsgSeq"`
+		MuteAllMember string `json:"MuteAllMember"`
+	}
+
+	// 修改话题资料（请求）
+	updateTopicReq struct {
+		GroupId       string `json:"GroupId"`                 // （必填）话题所属的社群ID
+		TopicId       string `json:"TopicId"`                 // （必填）话题ID
+		TopicName     string `json:"TopicName,omitempty"`     // （选填）话题名称
+		FaceUrl       string `json:"FaceUrl,omitempty"`       // （选填）话题头像 URL
+		Introduction  string `json:"Introduction,omitempty"`  // （选填）话题简介
+		Notification  string `json:"Notification,omitempty"`  // （选填）话题公告
+		MuteAllMember string `json:"MuteAllMember,omitempty"` // （选填）话题全员禁言状态，On 开启，Off 关闭
+		CustomString  string `json:"CustomString,omitempty"`  // （选填）话题自定义字段
+	}
+
+	// 直播群广播消息（请求）
+	sendBroadcastMessageReq struct {
+		FromUserId      string           `json:"From_Account,omitempty"`    // （选填）消息来源帐号
+		Random          uint32           `json:"Random"`                    // （必填）无符号32位整数
+		MsgBody         []*types.MsgBody `json:"MsgBody"`                   // （必填）消息体
+		CloudCustomData string           `json:"CloudCustomData,omitempty"` // （选填）消息自定义数据（云端保存，会发送到对端，程序卸载重装后还能拉取到）
+	}
+
+	// 直播群广播消息（响应）
+	sendBroadcastMessageResp struct {
+		types.ActionBaseResp
+		MsgSeq int `json:"MsgSeq"` // 广播消息序列号
+	}
+
+	// 获取直播群在线成员列表（请求）
+	fetchOnlineMembersReq struct {
+		GroupId   string `json:"GroupId"`   // （必填）操作的群ID
+		Timestamp int64  `json:"Timestamp"` // （选填）分页拉取的时间戳，首次拉取填0，后续填上次返回的 NextTimestamp
+	}
+
+	// 获取直播群在线成员列表（响应）
+	fetchOnlineMembersResp struct {
+		types.ActionBaseResp
+		NextTimestamp int64          `json:"NextTimestamp"` // 下一页的分页时间戳，为0时表示已拉取完毕
+		MemberList    []onlineMember `json:"MemberList"`    // 在线成员列表
+	}
+
+	// 直播群在线成员
+	onlineMember struct {
+		UserId string `json:"Member_Account"` // 成员ID
+	}
+
+	// FetchOnlineMembersRet 获取直播群在线成员列表（响应）
+	FetchOnlineMembersRet struct {
+		HasMore bool     // 是否还有更多数据
+		Next    int64    // 下一页的分页时间戳
+		List    []string // 在线成员ID列表
+	}
+
+	// BanOnlineMembersArg 封禁直播群在线成员（参数）
+	BanOnlineMembersArg struct {
+		GroupId     string   // （必填）直播群ID
+		UserIds     []string // （必填）封禁的成员ID列表
+		Duration    int64    // （必填）封禁时长，单位秒
+		Description string   // （选填）封禁原因
+	}
+
+	// 拉取群消息已读回执信息（请求）
+	getMessageReceiptsReq struct {
+		GroupId    string       `json:"GroupId"`    // （必填）群ID
+		MsgSeqList []msgSeqItem `json:"MsgSeqList"` // （必填）消息序列号列表
+	}
+
+	// 拉取群消息已读回执信息（响应）
+	getMessageReceiptsResp struct {
+		types.ActionBaseResp
+		ReadReceiptList []messageReceiptItem `json:"ReadReceiptList"` // 已读回执信息列表
+	}
+
+	// 消息已读回执信息
+	messageReceiptItem struct {
+		MsgSeq    int `json:"MsgSeq"`    // 消息序列号
+		ReadNum   int `json:"ReadNum"`   // 已读成员数
+		UnreadNum int `json:"UnReadNum"` // 未读成员数
+	}
+
+	// MessageReceipt 群消息已读回执信息
+	MessageReceipt struct {
+		MsgSeq    int // 消息序列号
+		ReadNum   int // 已读成员数
+		UnreadNum int // 未读成员数
+	}
+
+	// 拉取群消息已读回执详情（请求）
+	fetchReceiptMembersReq struct {
+		GroupId string `json:"GroupId"`          // （必填）群ID
+		MsgSeq  int    `json:"MsgSeq"`           // （必填）消息序列号
+		Num     int    `json:"Num"`              // （必填）单次拉取的成员数量
+		Cursor  string `json:"Cursor,omitempty"` // （选填）分页游标，首次拉取不填
+		Flag    int    `json:"Flag"`             // （必填）拉取的成员类型，0表示已读成员，1表示未读成员
+	}
+
+	// 拉取群消息已读回执详情（响应）
+	fetchReceiptMembersResp struct {
+		types.ActionBaseResp
+		ReadList   []receiptMember `json:"ReadList"`   // 已读成员列表
+		UnreadList []receiptMember `json:"UnreadList"` // 未读成员列表
+		Cursor     string          `json:"Cursor"`     // 下一页的分页游标
+		IsFinish   int             `json:"IsFinish"`   // 是否拉取完毕，1表示拉取完毕
+	}
+
+	// 已读回执成员
+	receiptMember struct {
+		UserId string `json:"Member_Account"` // 成员ID
+	}
+
+	// FetchReceiptMembersArg 拉取群消息已读回执详情（参数）
+	FetchReceiptMembersArg struct {
+		GroupId string      // （必填）群ID
+		MsgSeq  int         // （必填）消息序列号
+		Flag    ReceiptFlag // （选填）拉取的成员类型，默认拉取已读成员
+		Limit   int         // （选填）单次拉取的成员数量，默认100
+		Cursor  string      // （选填）分页游标，首次拉取不填
+	}
+
+	// FetchReceiptMembersRet 拉取群消息已读回执详情（响应）
+	FetchReceiptMembersRet struct {
+		HasMore bool     // 是否还有更多数据
+		Cursor  string   // 下一页的分页游标
+		List    []string // 成员ID列表
+	}
+
+	// 消息扩展项
+	extensionItem struct {
+		Key   string `json:"Key"`   // 扩展的 Key
+		Value string `json:"Value"` // 扩展的 Value
+		Seq   int64  `json:"Seq"`   // 扩展的版本号
+	}
+
+	// 设置群消息扩展（请求）
+	setMessageExtensionsReq struct {
+		GroupId       string          `json:"GroupId"`       // （必填）群ID
+		MsgSeq        int             `json:"MsgSeq"`        // （必填）消息序列号
+		ExtensionList []extensionItem `json:"ExtensionList"` // （必填）需要设置的扩展列表
+	}
+
+	// 设置群消息扩展（响应）
+	setMessageExtensionsResp struct {
+		types.ActionBaseResp
+		ResultList []setExtensionResult `json:"ResultList"` // 设置结果列表
+	}
+
+	// 消息扩展设置结果
+	setExtensionResult struct {
+		ErrorCode int           `json:"ErrorCode"` // 设置结果，0表示成功
+		Extension extensionItem `json:"Extension"` // 服务端当前的扩展
+	}
+
+	// 拉取群消息扩展（请求）
+	getMessageExtensionsReq struct {
+		GroupId  string `json:"GroupId"`  // （必填）群ID
+		MsgSeq   int    `json:"MsgSeq"`   // （必填）消息序列号
+		StartSeq int64  `json:"StartSeq"` // （必填）拉取的起始扩展 Seq，首次拉取填1
+	}
+
+	// 拉取群消息扩展（响应）
+	getMessageExtensionsResp struct {
+		types.ActionBaseResp
+		ExtensionList []extensionItem `json:"ExtensionList"` // 扩展列表
+		Complete      int             `json:"Complete"`      // 是否拉取完毕，1表示拉取完毕
+		LatestSeq     int64           `json:"LatestSeq"`     // 服务端最新的扩展 Seq
+	}
+
+	// PinnedMessage 群置顶消息
+	PinnedMessage struct {
+		MsgSeq   int    `json:"msg_seq"`            // 消息序列号
+		Operator string `json:"operator,omitempty"` // 置顶操作人ID
+		PinTime  int64  `json:"pin_time"`           // 置顶时间，UNIX 时间戳（单位：秒）
+	}
 )
@@ -15,6 +15,7 @@ import (
 	"github.com/d60-Lab/tencent-im/callback"
 	"github.com/d60-Lab/tencent-im/group"
 	"github.com/d60-Lab/tencent-im/internal/core"
+	"github.com/d60-Lab/tencent-im/internal/entity"
 	"github.com/d60-Lab/tencent-im/internal/sign"
 	"github.com/d60-Lab/tencent-im/mute"
 	"github.com/d60-Lab/tencent-im/operation"
@@ -27,6 +28,20 @@ import (
 
 type Error = core.Error
 
+type (
+	ValidationCode   = entity.ValidationCode   // 消息校验错误类型
+	ValidationError  = entity.ValidationError  // 消息校验错误
+	ValidationErrors = entity.ValidationErrors // 消息校验错误列表，消息发送前校验失败时返回
+)
+
+const (
+	ValidationCodeRequired   = entity.ValidationCodeRequired   // 缺少必填字段
+	ValidationCodeInvalid    = entity.ValidationCodeInvalid    // 字段取值无效
+	ValidationCodeOutOfRange = entity.ValidationCodeOutOfRange // 字段取值超出范围
+	ValidationCodeTooLarge   = entity.ValidationCodeTooLarge   // 消息超出大小限制
+	ValidationCodeConflict   = entity.ValidationCodeConflict   // 字段之间互斥或取值冲突
+)
+
 type (
 	IM interface {
 		// GetUserSig 获取UserSig签名
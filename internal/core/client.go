@@ -38,6 +38,8 @@ type Client interface {
 	Get(serviceName string, command string, data interface{}, resp interface{}) error
 	// Post POST请求
 	Post(serviceName string, command string, data interface{}, resp interface{}) error
+	// PostContext 携带上下文的POST请求，上下文取消时中止请求
+	PostContext(ctx context.Context, serviceName string, command string, data interface{}, resp interface{}) error
 	// Put PUT请求
 	Put(serviceName string, command string, data interface{}, resp interface{}) error
 	// Patch PATCH请求
@@ -101,32 +103,40 @@ func NewClient(opt *Options) Client {
 
 // Get GET请求
 func (c *client) Get(serviceName string, command string, data interface{}, resp interface{}) error {
-	return c.request(http.MethodGet, serviceName, command, data, resp)
+	return c.request(context.Background(), http.MethodGet, serviceName, command, data, resp)
 }
 
 // Post POST请求
 func (c *client) Post(serviceName string, command string, data interface{}, resp interface{}) error {
-	return c.request(http.MethodPost, serviceName, command, data, resp)
+	return c.request(context.Background(), http.MethodPost, serviceName, command, data, resp)
+}
+
+// PostContext 携带上下文的POST请求
+func (c *client) PostContext(ctx context.Context, serviceName string, command string, data interface{}, resp interface{}) error {
+	return c.request(ctx, http.MethodPost, serviceName, command, data, resp)
 }
 
 // Put PUT请求
 func (c *client) Put(serviceName string, command string, data interface{}, resp interface{}) error {
-	return c.request(http.MethodPut, serviceName, command, data, resp)
+	return c.request(context.Background(), http.MethodPut, serviceName, command, data, resp)
 }
 
 // Patch PATCH请求
 func (c *client) Patch(serviceName string, command string, data interface{}, resp interface{}) error {
-	return c.request(http.MethodPatch, serviceName, command, data, resp)
+	return c.request(context.Background(), http.MethodPatch, serviceName, command, data, resp)
 }
 
 // Delete DELETE请求
 func (c *client) Delete(serviceName string, command string, data interface{}, resp interface{}) error {
-	return c.request(http.MethodDelete, serviceName, command, data, resp)
+	return c.request(context.Background(), http.MethodDelete, serviceName, command, data, resp)
 }
 
 // request Request请求
-func (c *client) request(method, serviceName, command string, data, resp interface{}) error {
-	ctx := context.Background()
+func (c *client) request(ctx context.Context, method, serviceName, command string, data, resp interface{}) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
 	url := c.buildUrl(c.baseUrl, serviceName, command)
 
 	// 序列化请求数据
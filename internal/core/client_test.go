@@ -7,10 +7,14 @@
 package core
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
+
+	"github.com/d60-Lab/tencent-im/internal/types"
 )
 
 func TestNewClient(t *testing.T) {
@@ -83,6 +87,27 @@ func TestClient_Request(t *testing.T) {
 	}
 }
 
+func TestClient_PostContext(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ActionStatus":"OK","ErrorCode":0,"ErrorInfo":""}`))
+	}))
+	defer ts.Close()
+
+	client := NewClient(&Options{AppId: 1400000000, AppSecret: "test-secret", UserId: "admin", BaseUrl: ts.URL})
+
+	resp := &types.ActionBaseResp{}
+	if err := client.PostContext(context.Background(), "group_open_http_svc", "get_appid_group_list", nil, resp); err != nil {
+		t.Fatalf("PostContext() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := client.PostContext(ctx, "group_open_http_svc", "get_appid_group_list", nil, resp); !errors.Is(err, context.Canceled) {
+		t.Fatalf("PostContext() error = %v, want %v", err, context.Canceled)
+	}
+}
+
 func TestOptions_Defaults(t *testing.T) {
 	opt := &Options{
 		AppId:     1400000000,
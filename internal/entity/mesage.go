@@ -26,6 +26,7 @@ type Message struct {
 	lifeTime    int              // 消息离线保存时长（单位：秒），最长为7天（604800秒）
 	random      uint32           // 消息随机数，由随机函数产生
 	body        []*types.MsgBody // 消息体
+	customData  interface{}      // 消息自定义数据（云端保存）
 	offlinePush *offlinePush     // 推送实体
 }
 
@@ -72,7 +73,7 @@ func (m *Message) AddContent(msgContent ...interface{}) {
 	if len(msgContent) > 0 {
 		var msgType string
 		for _, content := range msgContent {
-			switch content.(type) {
+			switch c := content.(type) {
 			case types.MsgTextContent, *types.MsgTextContent:
 				msgType = enum.MsgText
 			case types.MsgLocationContent, *types.MsgLocationContent:
@@ -89,6 +90,14 @@ func (m *Message) AddContent(msgContent ...interface{}) {
 				msgType = enum.MsgFile
 			case types.MsgVideoContent, *types.MsgVideoContent:
 				msgType = enum.MsgVideo
+			case types.MsgRelayContent, *types.MsgRelayContent:
+				msgType = enum.MsgRelay
+			case types.MsgBody:
+				m.body = append(m.body, &c)
+				continue
+			case *types.MsgBody:
+				m.body = append(m.body, c)
+				continue
 			default:
 				msgType = ""
 			}
@@ -114,6 +123,25 @@ func (m *Message) GetBody() []*types.MsgBody {
 	return m.body
 }
 
+// AddBody 添加消息体（保留消息体原有的消息类型，用于拉取历史消息等场景）
+func (m *Message) AddBody(body ...*types.MsgBody) {
+	if m.body == nil {
+		m.body = make([]*types.MsgBody, 0, len(body))
+	}
+	m.body = append(m.body, body...)
+}
+
+// SetCustomData 设置消息自定义数据（云端保存，会发送到对端，程序卸载重装后还能拉取到）
+// 非字符串类型的数据将被序列化为字符串
+func (m *Message) SetCustomData(data interface{}) {
+	m.customData = data
+}
+
+// GetCustomData 获取消息自定义数据
+func (m *Message) GetCustomData() interface{} {
+	return m.customData
+}
+
 // OfflinePush 新建离线推送对象
 func (m *Message) OfflinePush() *offlinePush {
 	if m.offlinePush == nil {
@@ -123,48 +151,53 @@ func (m *Message) OfflinePush() *offlinePush {
 	return m.offlinePush
 }
 
-// GetOfflinePushInfo 获取离线推送消息
+// GetOfflinePushInfo 获取离线推送消息，已设置标题或内容模板时以消息内容渲染
 func (m *Message) GetOfflinePushInfo() *types.OfflinePushInfo {
 	if m.offlinePush == nil {
 		return nil
 	}
 
+	title, desc := m.offlinePush.render(m.sender, m.body)
+
 	return &types.OfflinePushInfo{
 		PushFlag:    m.offlinePush.pushFlag,
-		Title:       m.offlinePush.title,
-		Desc:        m.offlinePush.desc,
+		Title:       title,
+		Desc:        desc,
 		Ext:         m.offlinePush.ext,
 		AndroidInfo: m.offlinePush.androidInfo,
 		ApnsInfo:    m.offlinePush.apnsInfo,
 	}
 }
 
-// CheckLifeTimeArgError 检测参数错误
+// CheckLifeTimeArgError 检测消息离线保存时长参数错误
 func (m *Message) CheckLifeTimeArgError() error {
-	if m.body != nil && len(m.body) > 0 {
-		for _, item := range m.body {
-			if item.MsgType == "" {
-				return errInvalidMsgContent
-			}
-		}
-	} else {
-		return errNotSetMsgContent
-	}
+	v := &validator{}
+	m.validateLifeTime(v)
 
-	return nil
+	return v.err()
 }
 
-// CheckBodyArgError 检测参数错误
+// CheckBodyArgError 检测消息体参数错误（消息大小及各消息元素的必填字段）
 func (m *Message) CheckBodyArgError() error {
-	if m.body != nil && len(m.body) > 0 {
-		for _, item := range m.body {
-			if item.MsgType == "" {
-				return errInvalidMsgContent
-			}
-		}
-	} else {
+	if len(m.body) == 0 {
 		return errNotSetMsgContent
 	}
 
-	return nil
+	v := &validator{}
+	m.validateBody(v)
+
+	return v.err()
+}
+
+// CheckOfflinePushArgError 检测离线推送的厂商通道参数错误
+// 发送消息时执行，仅校验厂商通道设置，推送开关与推送内容冲突等完整校验请使用 Validate
+func (m *Message) CheckOfflinePushArgError() error {
+	if m.offlinePush == nil {
+		return nil
+	}
+
+	v := &validator{}
+	m.offlinePush.validateVendor(v)
+
+	return v.err()
 }
@@ -13,12 +13,16 @@ import (
 )
 
 type offlinePush struct {
-	pushFlag    int                // 推送标识。0表示推送，1表示不离线推送。
-	title       string             // 离线推送标题。该字段为 iOS 和 Android 共用。
-	desc        string             // 离线推送内容。
-	ext         string             // 离线推送透传内容。
-	androidInfo *types.AndroidInfo // Android离线推送消息
-	apnsInfo    *types.ApnsInfo    // IOS离线推送消息
+	pushFlag      int                                // 推送标识。0表示推送，1表示不离线推送。
+	title         string                             // 离线推送标题。该字段为 iOS 和 Android 共用。
+	desc          string                             // 离线推送内容。
+	ext           string                             // 离线推送透传内容。
+	androidInfo   *types.AndroidInfo                 // Android离线推送消息
+	apnsInfo      *types.ApnsInfo                    // IOS离线推送消息
+	titleTemplate string                             // 离线推送标题模板
+	descTemplate  string                             // 离线推送内容模板
+	summarizer    func(body []*types.MsgBody) string // 消息摘要生成函数
+	errs          ValidationErrors                   // 厂商通道设置错误
 }
 
 func newOfflinePush() *offlinePush {
@@ -30,14 +34,14 @@ func (o *offlinePush) SetPushFlag(pushFlag types.PushFlag) {
 	o.pushFlag = int(pushFlag)
 }
 
-// SetTitle 设置离线推送标题
+// SetTitle 设置离线推送标题，将覆盖已设置的标题模板
 func (o *offlinePush) SetTitle(title string) {
-	o.title = title
+	o.title, o.titleTemplate = title, ""
 }
 
-// SetDesc 设置离线推送内容
+// SetDesc 设置离线推送内容，将覆盖已设置的内容模板
 func (o *offlinePush) SetDesc(desc string) {
-	o.desc = desc
+	o.desc, o.descTemplate = desc, ""
 }
 
 // SetExt 设置离线推送透传内容
@@ -148,3 +152,94 @@ func (o *offlinePush) SetApnsMutableContent(mutable types.MutableContent) {
 	}
 	o.apnsInfo.MutableContent = int(mutable)
 }
+
+// SetAndroidHuaWeiCategory 设置华为推送通知消息的场景标识
+func (o *offlinePush) SetAndroidHuaWeiCategory(category string) {
+	o.android().HuaWeiCategory = category
+}
+
+// SetAndroidHuaWeiImage 设置华为推送通知栏消息右侧小图标的地址
+func (o *offlinePush) SetAndroidHuaWeiImage(image string) {
+	o.android().HuaWeiImage = image
+}
+
+// SetAndroidHonorImportance 设置荣耀推送通知消息分类
+func (o *offlinePush) SetAndroidHonorImportance(importance types.HonorImportance) {
+	o.android().HonorImportance = string(importance)
+}
+
+// SetAndroidHonorImage 设置荣耀推送通知栏消息右侧小图标的地址
+func (o *offlinePush) SetAndroidHonorImage(image string) {
+	o.android().HonorImage = image
+}
+
+// SetAndroidMeiZuChannelId 设置魅族手机 Flyme 的通知渠道字段
+func (o *offlinePush) SetAndroidMeiZuChannelId(channelId string) {
+	o.android().MeiZuChannelID = channelId
+}
+
+// SetAndroidVivoCategory 设置VIVO 手机推送消息的二级分类
+func (o *offlinePush) SetAndroidVivoCategory(category string) {
+	o.android().VIVOCategory = category
+}
+
+// SetAndroidOppoCategory 设置OPPO 手机推送消息的分类
+func (o *offlinePush) SetAndroidOppoCategory(category string) {
+	o.android().OPPOCategory = category
+}
+
+// SetAndroidOppoNotifyLevel 设置OPPO 手机通知栏消息的提醒等级
+func (o *offlinePush) SetAndroidOppoNotifyLevel(level types.OPPONotifyLevel) {
+	o.android().OPPONotifyLevel = int(level)
+}
+
+// SetAndroidGoogleImage 设置Google 推送通知栏消息的图片地址
+func (o *offlinePush) SetAndroidGoogleImage(image string) {
+	o.android().GoogleImage = image
+}
+
+// SetAndroidFCMPushType 设置FCM 推送消息类型（通知消息或数据消息）
+func (o *offlinePush) SetAndroidFCMPushType(pushType types.FCMPushType) {
+	o.android().FCMPushType = int(pushType)
+}
+
+// SetAndroidTPNSChannelId 设置TPNS 推送的通知渠道字段
+func (o *offlinePush) SetAndroidTPNSChannelId(channelId string) {
+	o.android().TPNSChannelID = channelId
+}
+
+// SetAndroidTPNSImage 设置TPNS 推送通知栏消息的图片地址
+func (o *offlinePush) SetAndroidTPNSImage(image string) {
+	o.android().TPNSImage = image
+}
+
+// SetApnsSound 设置APNs推送的声音文件名
+func (o *offlinePush) SetApnsSound(sound string) {
+	o.apns().Sound = sound
+}
+
+// SetApnsInterruptionLevel 设置iOS 15 及以上的通知中断级别
+func (o *offlinePush) SetApnsInterruptionLevel(level types.InterruptionLevel) {
+	o.apns().InterruptionLevel = string(level)
+}
+
+// SetApnsThreadId 设置APNs推送的通知分组标识
+func (o *offlinePush) SetApnsThreadId(threadId string) {
+	o.apns().ThreadId = threadId
+}
+
+// android 获取Android离线推送消息，不存在时创建
+func (o *offlinePush) android() *types.AndroidInfo {
+	if o.androidInfo == nil {
+		o.androidInfo = &types.AndroidInfo{}
+	}
+	return o.androidInfo
+}
+
+// apns 获取IOS离线推送消息，不存在时创建
+func (o *offlinePush) apns() *types.ApnsInfo {
+	if o.apnsInfo == nil {
+		o.apnsInfo = &types.ApnsInfo{}
+	}
+	return o.apnsInfo
+}
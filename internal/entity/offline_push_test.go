@@ -0,0 +1,187 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 离线推送单元测试
+ */
+
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/d60-Lab/tencent-im/internal/enum"
+	"github.com/d60-Lab/tencent-im/internal/types"
+)
+
+func TestOfflinePush_Vendor(t *testing.T) {
+	o := newOfflinePush()
+	o.SetVendorSound(enum.PushVendorHuaWei, "ring.mp3")
+	o.SetVendorSound(enum.PushVendorXiaoMi, "ring.mp3")
+	o.SetVendorSound(enum.PushVendorAPNs, "ring.caf")
+	o.SetVendorChannelId(enum.PushVendorMeiZu, "im")
+	o.SetVendorChannelId(enum.PushVendorFCM, "im")
+	o.SetVendorImage(enum.PushVendorHonor, "https://img")
+	o.SetVendorImage(enum.PushVendorAPNs, "https://img")
+	o.SetVendorCategory(enum.PushVendorVIVO, "IM")
+	o.SetPriority(enum.PushPriorityHigh)
+
+	if err := o.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	a, i := o.androidInfo, o.apnsInfo
+	if a.Sound != "ring.mp3" || a.MeiZuChannelID != "im" || a.GoogleChannelID != "im" || a.HonorImage != "https://img" || a.VIVOCategory != "IM" {
+		t.Fatalf("unexpected android info: %+v", a)
+	}
+	if a.HuaWeiImportance != "NORMAL" || a.HonorImportance != "NORMAL" || a.VIVOClassification != 1 || a.OPPONotifyLevel != 16 {
+		t.Fatalf("unexpected android priority: %+v", a)
+	}
+	if i.Sound != "ring.caf" || i.Image != "https://img" || i.MutableContent != 1 || i.InterruptionLevel != "time-sensitive" {
+		t.Fatalf("unexpected apns info: %+v", i)
+	}
+}
+
+func TestOfflinePush_Validate(t *testing.T) {
+	tests := []struct {
+		name  string
+		setup func(o *offlinePush)
+		want  map[string]ValidationCode
+	}{
+		{
+			name: "android sound conflict",
+			setup: func(o *offlinePush) {
+				o.SetVendorSound(enum.PushVendorHuaWei, "a.mp3")
+				o.SetVendorSound(enum.PushVendorOPPO, "b.mp3")
+			},
+			want: map[string]ValidationCode{"OfflinePushInfo.OPPO.Sound": ValidationCodeConflict},
+		},
+		{
+			name: "unsupported vendor option",
+			setup: func(o *offlinePush) {
+				o.SetVendorBadge(enum.PushVendorXiaoMi, enum.BadgeModeIgnore)
+				o.SetVendorPriority(enum.PushVendorFCM, enum.PushPriorityLow)
+			},
+			want: map[string]ValidationCode{
+				"OfflinePushInfo.XiaoMi.BadgeMode": ValidationCodeInvalid,
+				"OfflinePushInfo.FCM.Priority":     ValidationCodeInvalid,
+			},
+		},
+		{
+			name:  "invalid priority",
+			setup: func(o *offlinePush) { o.SetPriority(9) },
+			want:  map[string]ValidationCode{"OfflinePushInfo.Priority": ValidationCodeInvalid},
+		},
+		{
+			name: "push disabled with content",
+			setup: func(o *offlinePush) {
+				o.SetPushFlag(enum.PushFlagNo)
+				o.SetTitle("title")
+			},
+			want: map[string]ValidationCode{"OfflinePushInfo.PushFlag": ValidationCodeConflict},
+		},
+		{
+			name: "fcm data message with image",
+			setup: func(o *offlinePush) {
+				o.SetAndroidFCMPushType(enum.FCMPushTypeData)
+				o.SetAndroidGoogleImage("https://img")
+			},
+			want: map[string]ValidationCode{"OfflinePushInfo.AndroidInfo.FCMPushType": ValidationCodeConflict},
+		},
+		{
+			name: "huawei category with low importance",
+			setup: func(o *offlinePush) {
+				o.SetAndroidHuaWeiImportance(enum.HuaWeiImportanceLow)
+				o.SetAndroidHuaWeiCategory("IM")
+				o.SetAndroidHuaWeiImage("http://img")
+			},
+			want: map[string]ValidationCode{
+				"OfflinePushInfo.AndroidInfo.HuaWeiCategory": ValidationCodeConflict,
+				"OfflinePushInfo.AndroidInfo.HuaWeiImage":    ValidationCodeInvalid,
+			},
+		},
+		{
+			name: "apns image without mutable content",
+			setup: func(o *offlinePush) {
+				o.SetApnsImage("https://img")
+				o.SetApnsInterruptionLevel("urgent")
+			},
+			want: map[string]ValidationCode{
+				"OfflinePushInfo.ApnsInfo.MutableContent":    ValidationCodeConflict,
+				"OfflinePushInfo.ApnsInfo.InterruptionLevel": ValidationCodeInvalid,
+			},
+		},
+		{
+			name:  "oppo notify level",
+			setup: func(o *offlinePush) { o.SetAndroidOppoNotifyLevel(3) },
+			want:  map[string]ValidationCode{"OfflinePushInfo.AndroidInfo.OPPONotifyLevel": ValidationCodeInvalid},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := newOfflinePush()
+			tt.setup(o)
+
+			var errs ValidationErrors
+			if err := o.Validate(); !errors.As(err, &errs) {
+				t.Fatalf("expected ValidationErrors, got %v", err)
+			}
+
+			got := make(map[string]ValidationCode, len(errs))
+			for _, err := range errs {
+				got[err.Field] = err.Code
+			}
+			if len(got) != len(tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+			for field, code := range tt.want {
+				if got[field] != code {
+					t.Fatalf("field %s: got %q, want %q (all: %v)", field, got[field], code, got)
+				}
+			}
+		})
+	}
+}
+
+func TestMessage_CheckOfflinePushArgError(t *testing.T) {
+	m := &Message{}
+	m.OfflinePush().SetPushFlag(enum.PushFlagNo)
+	m.OfflinePush().SetAndroidHuaWeiImportance(enum.HuaWeiImportanceNormal)
+	m.OfflinePush().SetApnsBadgeMode(enum.BadgeModeIgnore)
+
+	if err := m.CheckOfflinePushArgError(); err != nil {
+		t.Fatalf("CheckOfflinePushArgError() error = %v, want nil for legacy settings", err)
+	}
+	if err := m.OfflinePush().Validate(); err == nil {
+		t.Fatal("Validate() error = nil, want push flag conflict")
+	}
+
+	m.OfflinePush().SetAndroidOppoNotifyLevel(3)
+	if err := m.CheckOfflinePushArgError(); err == nil {
+		t.Fatal("CheckOfflinePushArgError() error = nil, want vendor field error")
+	}
+}
+
+func TestMessage_GetOfflinePushInfo_Template(t *testing.T) {
+	m := &Message{}
+	m.SetSender("alice")
+	m.AddContent(&types.MsgTextContent{Text: "hello"}, &types.MsgImageContent{UUID: "u"})
+	m.OfflinePush().SetTitleTemplate("{sender}")
+	m.OfflinePush().SetDescTemplate("{sender}: {summary}")
+
+	info := m.GetOfflinePushInfo()
+	if info.Title != "alice" || info.Desc != "alice: hello [Image]" {
+		t.Fatalf("unexpected info: %+v", info)
+	}
+
+	m.OfflinePush().SetSummarizer(func(body []*types.MsgBody) string { return "custom" })
+	if info = m.GetOfflinePushInfo(); info.Desc != "alice: custom" {
+		t.Fatalf("unexpected desc: %s", info.Desc)
+	}
+
+	m.OfflinePush().SetTitle("fixed")
+	if info = m.GetOfflinePushInfo(); info.Title != "fixed" {
+		t.Fatalf("unexpected title: %s", info.Title)
+	}
+}
@@ -0,0 +1,383 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 离线推送厂商通道适配、标题模板及校验
+ */
+
+package entity
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/d60-Lab/tencent-im/internal/enum"
+	"github.com/d60-Lab/tencent-im/internal/types"
+)
+
+const (
+	TemplateVarSender  = "{sender}"  // 离线推送模板变量：发送方UserId
+	TemplateVarSummary = "{summary}" // 离线推送模板变量：消息摘要
+)
+
+// SetVendorSound 设置指定厂商通道的提示音
+// Android 各厂商通道共用同一提示音，为不同 Android 厂商设置不同提示音时校验将失败
+func (o *offlinePush) SetVendorSound(vendor types.PushVendor, sound string) {
+	switch vendor {
+	case enum.PushVendorAPNs:
+		o.apns().Sound = sound
+	case enum.PushVendorHuaWei, enum.PushVendorHonor, enum.PushVendorXiaoMi, enum.PushVendorOPPO,
+		enum.PushVendorVIVO, enum.PushVendorMeiZu, enum.PushVendorFCM, enum.PushVendorTPNS:
+		android := o.android()
+		if android.Sound != "" && android.Sound != sound {
+			o.addError(vendor, "Sound", ValidationCodeConflict, fmt.Sprintf("android vendors share one sound, %q is already set", android.Sound))
+			return
+		}
+		android.Sound = sound
+	default:
+		o.unsupported(vendor, "Sound")
+	}
+}
+
+// SetVendorChannelId 设置指定厂商通道的通知渠道
+func (o *offlinePush) SetVendorChannelId(vendor types.PushVendor, channelId string) {
+	switch vendor {
+	case enum.PushVendorHuaWei:
+		o.android().HuaWeiChannelID = channelId
+	case enum.PushVendorXiaoMi:
+		o.android().XiaoMiChannelID = channelId
+	case enum.PushVendorOPPO:
+		o.android().OPPOChannelID = channelId
+	case enum.PushVendorMeiZu:
+		o.android().MeiZuChannelID = channelId
+	case enum.PushVendorFCM:
+		o.android().GoogleChannelID = channelId
+	case enum.PushVendorTPNS:
+		o.android().TPNSChannelID = channelId
+	default:
+		o.unsupported(vendor, "ChannelID")
+	}
+}
+
+// SetVendorImage 设置指定厂商通道的通知图片，APNs 通道将同时开启推送扩展
+func (o *offlinePush) SetVendorImage(vendor types.PushVendor, image string) {
+	switch vendor {
+	case enum.PushVendorHuaWei:
+		o.android().HuaWeiImage = image
+	case enum.PushVendorHonor:
+		o.android().HonorImage = image
+	case enum.PushVendorFCM:
+		o.android().GoogleImage = image
+	case enum.PushVendorTPNS:
+		o.android().TPNSImage = image
+	case enum.PushVendorAPNs:
+		apns := o.apns()
+		apns.Image = image
+		apns.MutableContent = int(enum.MutableContentEnable)
+	default:
+		o.unsupported(vendor, "Image")
+	}
+}
+
+// SetVendorBadge 设置指定厂商通道的徽章计数模式
+func (o *offlinePush) SetVendorBadge(vendor types.PushVendor, badgeMode types.BadgeMode) {
+	switch vendor {
+	case enum.PushVendorAPNs:
+		o.apns().BadgeMode = int(badgeMode)
+	default:
+		o.unsupported(vendor, "BadgeMode")
+	}
+}
+
+// SetVendorCategory 设置指定厂商通道的消息分类
+func (o *offlinePush) SetVendorCategory(vendor types.PushVendor, category string) {
+	switch vendor {
+	case enum.PushVendorHuaWei:
+		o.android().HuaWeiCategory = category
+	case enum.PushVendorVIVO:
+		o.android().VIVOCategory = category
+	case enum.PushVendorOPPO:
+		o.android().OPPOCategory = category
+	default:
+		o.unsupported(vendor, "Category")
+	}
+}
+
+// SetVendorPriority 设置指定厂商通道的优先级，将映射为各厂商的消息分类或提醒等级
+func (o *offlinePush) SetVendorPriority(vendor types.PushVendor, priority types.PushPriority) {
+	switch priority {
+	case enum.PushPriorityLow, enum.PushPriorityNormal, enum.PushPriorityHigh:
+	default:
+		o.addError(vendor, "Priority", ValidationCodeInvalid, fmt.Sprintf("unsupported priority %d", priority))
+		return
+	}
+
+	switch vendor {
+	case enum.PushVendorHuaWei:
+		o.android().HuaWeiImportance = string(enum.HuaWeiImportanceNormal)
+		if priority == enum.PushPriorityLow {
+			o.android().HuaWeiImportance = string(enum.HuaWeiImportanceLow)
+		}
+	case enum.PushVendorHonor:
+		o.android().HonorImportance = string(enum.HonorImportanceNormal)
+		if priority == enum.PushPriorityLow {
+			o.android().HonorImportance = string(enum.HonorImportanceLow)
+		}
+	case enum.PushVendorVIVO:
+		o.android().VIVOClassification = int(enum.VivoClassificationSystem)
+		if priority == enum.PushPriorityLow {
+			o.android().VIVOClassification = int(enum.VivoClassificationOperation)
+		}
+	case enum.PushVendorOPPO:
+		switch priority {
+		case enum.PushPriorityLow:
+			o.android().OPPONotifyLevel = int(enum.OPPONotifyLevelBar)
+		case enum.PushPriorityNormal:
+			o.android().OPPONotifyLevel = int(enum.OPPONotifyLevelLockScreen)
+		default:
+			o.android().OPPONotifyLevel = int(enum.OPPONotifyLevelFull)
+		}
+	case enum.PushVendorAPNs:
+		switch priority {
+		case enum.PushPriorityLow:
+			o.apns().InterruptionLevel = string(enum.InterruptionLevelPassive)
+		case enum.PushPriorityNormal:
+			o.apns().InterruptionLevel = string(enum.InterruptionLevelActive)
+		default:
+			o.apns().InterruptionLevel = string(enum.InterruptionLevelTimeSensitive)
+		}
+	default:
+		o.unsupported(vendor, "Priority")
+	}
+}
+
+// SetPriority 为所有支持优先级的厂商通道（华为、荣耀、VIVO、OPPO、APNs）设置优先级
+func (o *offlinePush) SetPriority(priority types.PushPriority) {
+	if priority < enum.PushPriorityLow || priority > enum.PushPriorityHigh {
+		o.errs = append(o.errs, &ValidationError{
+			Field:   "OfflinePushInfo.Priority",
+			Code:    ValidationCodeInvalid,
+			Message: fmt.Sprintf("unsupported priority %d", priority),
+		})
+		return
+	}
+
+	for _, vendor := range []types.PushVendor{
+		enum.PushVendorHuaWei,
+		enum.PushVendorHonor,
+		enum.PushVendorVIVO,
+		enum.PushVendorOPPO,
+		enum.PushVendorAPNs,
+	} {
+		o.SetVendorPriority(vendor, priority)
+	}
+}
+
+// SetTitleTemplate 设置离线推送标题模板，发送时以消息内容渲染，将覆盖已设置的标题
+// 支持的变量：{sender} 发送方UserId，{summary} 消息摘要
+func (o *offlinePush) SetTitleTemplate(template string) {
+	o.title, o.titleTemplate = "", template
+}
+
+// SetDescTemplate 设置离线推送内容模板，发送时以消息内容渲染，将覆盖已设置的内容
+// 支持的变量：{sender} 发送方UserId，{summary} 消息摘要
+func (o *offlinePush) SetDescTemplate(template string) {
+	o.desc, o.descTemplate = "", template
+}
+
+// SetSummarizer 设置模板变量 {summary} 的生成函数，默认拼接文本消息并以占位符表示其他消息元素
+func (o *offlinePush) SetSummarizer(fn func(body []*types.MsgBody) string) {
+	o.summarizer = fn
+}
+
+// Validate 校验离线推送设置，校验失败时返回 ValidationErrors
+func (o *offlinePush) Validate() error {
+	v := &validator{}
+	o.validate(v)
+
+	return v.err()
+}
+
+// render 以消息内容渲染标题及内容模板
+func (o *offlinePush) render(sender string, body []*types.MsgBody) (title, desc string) {
+	title, desc = o.title, o.desc
+	if o.titleTemplate == "" && o.descTemplate == "" {
+		return
+	}
+
+	summarizer := o.summarizer
+	if summarizer == nil {
+		summarizer = summarize
+	}
+
+	r := strings.NewReplacer(TemplateVarSender, sender, TemplateVarSummary, summarizer(body))
+	if o.titleTemplate != "" {
+		title = r.Replace(o.titleTemplate)
+	}
+	if o.descTemplate != "" {
+		desc = r.Replace(o.descTemplate)
+	}
+
+	return
+}
+
+// validate 校验离线推送设置
+func (o *offlinePush) validate(v *validator) {
+	const field = "OfflinePushInfo"
+
+	switch types.PushFlag(o.pushFlag) {
+	case enum.PushFlagYes:
+	case enum.PushFlagNo:
+		if o.title != "" || o.desc != "" || o.titleTemplate != "" || o.descTemplate != "" || o.androidInfo != nil || o.apnsInfo != nil {
+			v.add(field+".PushFlag", ValidationCodeConflict, "offline push is disabled but push content is set")
+		}
+	default:
+		v.add(field+".PushFlag", ValidationCodeInvalid, fmt.Sprintf("unsupported push flag %d", o.pushFlag))
+	}
+
+	if a := o.androidInfo; a != nil {
+		v.oneOf(field+".AndroidInfo.HuaWeiImportance", a.HuaWeiImportance, "", string(enum.HuaWeiImportanceLow), string(enum.HuaWeiImportanceNormal))
+	}
+
+	if a := o.apnsInfo; a != nil {
+		f := field + ".ApnsInfo"
+
+		switch types.BadgeMode(a.BadgeMode) {
+		case enum.BadgeModeNormal, enum.BadgeModeIgnore:
+		default:
+			v.add(f+".BadgeMode", ValidationCodeInvalid, fmt.Sprintf("unsupported badge mode %d", a.BadgeMode))
+		}
+
+		if a.Image != "" && a.MutableContent != int(enum.MutableContentEnable) {
+			v.add(f+".MutableContent", ValidationCodeConflict, "must be 1 when Image is set")
+		}
+	}
+
+	o.validateVendor(v)
+}
+
+// validateVendor 校验厂商通道设置（仅校验厂商通道设置方法及新增的厂商字段，发送消息时执行）
+func (o *offlinePush) validateVendor(v *validator) {
+	const field = "OfflinePushInfo"
+
+	v.errs = append(v.errs, o.errs...)
+
+	if a := o.androidInfo; a != nil {
+		f := field + ".AndroidInfo"
+		v.oneOf(f+".HonorImportance", a.HonorImportance, "", string(enum.HonorImportanceLow), string(enum.HonorImportanceNormal))
+		v.https(f+".HuaWeiImage", a.HuaWeiImage)
+		v.https(f+".HonorImage", a.HonorImage)
+
+		if a.HuaWeiCategory != "" && a.HuaWeiImportance == string(enum.HuaWeiImportanceLow) {
+			v.add(f+".HuaWeiCategory", ValidationCodeConflict, "requires HuaWeiImportance to be NORMAL")
+		}
+
+		switch types.OPPONotifyLevel(a.OPPONotifyLevel) {
+		case 0, enum.OPPONotifyLevelBar, enum.OPPONotifyLevelLockScreen, enum.OPPONotifyLevelFull:
+		default:
+			v.add(f+".OPPONotifyLevel", ValidationCodeInvalid, fmt.Sprintf("unsupported notify level %d", a.OPPONotifyLevel))
+		}
+
+		switch types.FCMPushType(a.FCMPushType) {
+		case enum.FCMPushTypeNotification:
+		case enum.FCMPushTypeData:
+			if a.GoogleChannelID != "" || a.GoogleImage != "" {
+				v.add(f+".FCMPushType", ValidationCodeConflict, "data messages are not displayed, GoogleChannelID and GoogleImage must be empty")
+			}
+		default:
+			v.add(f+".FCMPushType", ValidationCodeInvalid, fmt.Sprintf("unsupported push type %d", a.FCMPushType))
+		}
+	}
+
+	if a := o.apnsInfo; a != nil {
+		v.oneOf(field+".ApnsInfo.InterruptionLevel", a.InterruptionLevel, "",
+			string(enum.InterruptionLevelPassive),
+			string(enum.InterruptionLevelActive),
+			string(enum.InterruptionLevelTimeSensitive),
+			string(enum.InterruptionLevelCritical),
+		)
+	}
+}
+
+// unsupported 记录厂商通道不支持的设置
+func (o *offlinePush) unsupported(vendor types.PushVendor, option string) {
+	o.addError(vendor, option, ValidationCodeInvalid, fmt.Sprintf("is not supported by vendor %q", vendor))
+}
+
+// addError 记录厂商通道设置错误
+func (o *offlinePush) addError(vendor types.PushVendor, option string, code ValidationCode, message string) {
+	o.errs = append(o.errs, &ValidationError{
+		Field:   fmt.Sprintf("OfflinePushInfo.%s.%s", vendor, option),
+		Code:    code,
+		Message: message,
+	})
+}
+
+// oneOf 校验字段取值是否在可选范围内
+func (v *validator) oneOf(field, value string, values ...string) {
+	for _, item := range values {
+		if value == item {
+			return
+		}
+	}
+
+	v.add(field, ValidationCodeInvalid, fmt.Sprintf("unsupported value %q", value))
+}
+
+// https 校验图片地址是否为 HTTPS 地址
+func (v *validator) https(field, url string) {
+	if url != "" && !strings.HasPrefix(url, "https://") {
+		v.add(field, ValidationCodeInvalid, "must be an HTTPS url")
+	}
+}
+
+// summarize 生成消息摘要，拼接文本消息并以占位符表示其他消息元素
+func summarize(body []*types.MsgBody) string {
+	parts := make([]string, 0, len(body))
+	for _, item := range body {
+		if item == nil {
+			continue
+		}
+
+		switch c := item.MsgContent.(type) {
+		case types.MsgTextContent:
+			parts = append(parts, c.Text)
+		case *types.MsgTextContent:
+			parts = append(parts, c.Text)
+		case types.MsgCustomContent:
+			parts = append(parts, summaryOf(c.Desc, "[Custom Message]"))
+		case *types.MsgCustomContent:
+			parts = append(parts, summaryOf(c.Desc, "[Custom Message]"))
+		case types.MsgLocationContent:
+			parts = append(parts, "[Location] "+c.Desc)
+		case *types.MsgLocationContent:
+			parts = append(parts, "[Location] "+c.Desc)
+		case types.MsgFileContent:
+			parts = append(parts, "[File] "+c.FileName)
+		case *types.MsgFileContent:
+			parts = append(parts, "[File] "+c.FileName)
+		case types.MsgRelayContent:
+			parts = append(parts, summaryOf(c.Title, "[Chat History]"))
+		case *types.MsgRelayContent:
+			parts = append(parts, summaryOf(c.Title, "[Chat History]"))
+		case types.MsgImageContent, *types.MsgImageContent:
+			parts = append(parts, "[Image]")
+		case types.MsgSoundContent, *types.MsgSoundContent:
+			parts = append(parts, "[Voice]")
+		case types.MsgVideoContent, *types.MsgVideoContent:
+			parts = append(parts, "[Video]")
+		case types.MsgFaceContent, *types.MsgFaceContent:
+			parts = append(parts, "[Face]")
+		}
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// summaryOf 获取摘要文本，为空时使用占位符
+func summaryOf(text, placeholder string) string {
+	if text == "" {
+		return placeholder
+	}
+
+	return text
+}
@@ -0,0 +1,325 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 消息发送前校验
+ */
+
+package entity
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/d60-Lab/tencent-im/internal/conv"
+	"github.com/d60-Lab/tencent-im/internal/enum"
+	"github.com/d60-Lab/tencent-im/internal/types"
+)
+
+const (
+	MaxMsgSize     = 12 << 10   // 消息（消息体及自定义数据）序列化后的最大字节数
+	MaxMsgTextSize = MaxMsgSize // 单个文本消息元素内容（UTF-8编码）的最大字节数
+	MaxMsgLifeTime = 604800     // 消息离线保存的最长时长（单位：秒）
+
+	downloadFlagUrl = 2 // 可通过 URL 地址直接下载
+)
+
+const (
+	ValidationCodeRequired   ValidationCode = "required"     // 缺少必填字段
+	ValidationCodeInvalid    ValidationCode = "invalid"      // 字段取值无效
+	ValidationCodeOutOfRange ValidationCode = "out_of_range" // 字段取值超出范围
+	ValidationCodeTooLarge   ValidationCode = "too_large"    // 消息超出大小限制
+	ValidationCodeConflict   ValidationCode = "conflict"     // 字段之间互斥或取值冲突
+)
+
+type (
+	// ValidationCode 校验错误类型
+	ValidationCode string
+
+	// ValidationError 消息校验错误
+	ValidationError struct {
+		Field   string         // 字段路径，如 MsgBody[0].MsgContent.UUID
+		Code    ValidationCode // 错误类型
+		Message string         // 错误信息
+	}
+
+	// ValidationErrors 消息校验错误列表
+	ValidationErrors []*ValidationError
+
+	// validator 校验错误收集器
+	validator struct {
+		errs ValidationErrors
+	}
+)
+
+// Error 错误信息
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// Error 错误信息
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, err := range e {
+		msgs = append(msgs, err.Error())
+	}
+
+	return strings.Join(msgs, "; ")
+}
+
+// Size 计算消息体及自定义数据序列化后的字节数
+// 自定义数据以 JSON 字符串发送，按转义后的长度计算
+func (m *Message) Size() (int, error) {
+	b, err := json.Marshal(m.body)
+	if err != nil {
+		return 0, err
+	}
+
+	size := len(b)
+	if data := conv.String(m.customData); data != "" {
+		if b, err = json.Marshal(data); err != nil {
+			return 0, err
+		}
+		size += len(b)
+	}
+
+	return size, nil
+}
+
+// Validate 发送前校验消息，校验失败时返回 ValidationErrors
+// 校验内容包括消息离线保存时长、消息大小（12KB）、文本长度、各消息元素的必填字段及离线推送设置
+func (m *Message) Validate() error {
+	v := &validator{}
+	m.validateLifeTime(v)
+	m.validateBody(v)
+	m.validateOfflinePush(v)
+
+	return v.err()
+}
+
+// validateOfflinePush 校验离线推送设置
+func (m *Message) validateOfflinePush(v *validator) {
+	if m.offlinePush != nil {
+		m.offlinePush.validate(v)
+	}
+}
+
+// validateLifeTime 校验消息离线保存时长
+func (m *Message) validateLifeTime(v *validator) {
+	if m.lifeTime < 0 || m.lifeTime > MaxMsgLifeTime {
+		v.add("MsgLifeTime", ValidationCodeOutOfRange, fmt.Sprintf("%s, must be between 0 and %d seconds", errInvalidMsgLifeTime, MaxMsgLifeTime))
+	}
+}
+
+// validateBody 校验消息体
+func (m *Message) validateBody(v *validator) {
+	if len(m.body) == 0 {
+		v.add("MsgBody", ValidationCodeRequired, errNotSetMsgContent.Error())
+		return
+	}
+
+	if size, err := m.Size(); err != nil {
+		v.add("MsgBody", ValidationCodeInvalid, err.Error())
+	} else if size > MaxMsgSize {
+		v.add("MsgBody", ValidationCodeTooLarge, fmt.Sprintf("message size %d bytes exceeds the limit of %d bytes", size, MaxMsgSize))
+	}
+
+	v.body("MsgBody", m.body)
+}
+
+// body 校验消息元素列表
+func (v *validator) body(field string, body []*types.MsgBody) {
+	for i, item := range body {
+		f := fmt.Sprintf("%s[%d]", field, i)
+		if item == nil {
+			v.add(f, ValidationCodeRequired, "message element is nil")
+			continue
+		}
+
+		v.element(f, item)
+	}
+}
+
+// element 校验消息元素
+func (v *validator) element(field string, item *types.MsgBody) {
+	content := field + ".MsgContent"
+
+	switch c := item.MsgContent.(type) {
+	case types.MsgTextContent:
+		v.text(content, &c)
+	case *types.MsgTextContent:
+		v.text(content, c)
+	case types.MsgLocationContent:
+		v.location(content, &c)
+	case *types.MsgLocationContent:
+		v.location(content, c)
+	case types.MsgFaceContent, *types.MsgFaceContent:
+	case types.MsgCustomContent:
+		v.custom(content, &c)
+	case *types.MsgCustomContent:
+		v.custom(content, c)
+	case types.MsgSoundContent:
+		v.sound(content, &c)
+	case *types.MsgSoundContent:
+		v.sound(content, c)
+	case types.MsgImageContent:
+		v.image(content, &c)
+	case *types.MsgImageContent:
+		v.image(content, c)
+	case types.MsgFileContent:
+		v.file(content, &c)
+	case *types.MsgFileContent:
+		v.file(content, c)
+	case types.MsgVideoContent:
+		v.video(content, &c)
+	case *types.MsgVideoContent:
+		v.video(content, c)
+	case types.MsgRelayContent:
+		v.relay(content, &c)
+	case *types.MsgRelayContent:
+		v.relay(content, c)
+	default:
+		if item.MsgType == "" {
+			v.add(field+".MsgType", ValidationCodeInvalid, errInvalidMsgContent.Error())
+		}
+	}
+}
+
+// text 校验文本消息元素
+func (v *validator) text(field string, c *types.MsgTextContent) {
+	v.required(field+".Text", c.Text)
+
+	if size := len(c.Text); size > MaxMsgTextSize {
+		v.add(field+".Text", ValidationCodeTooLarge, fmt.Sprintf("text size %d bytes exceeds the limit of %d bytes", size, MaxMsgTextSize))
+	}
+}
+
+// location 校验地理位置消息元素
+func (v *validator) location(field string, c *types.MsgLocationContent) {
+	v.required(field+".Desc", c.Desc)
+
+	if c.Latitude < -90 || c.Latitude > 90 {
+		v.add(field+".Latitude", ValidationCodeOutOfRange, "must be between -90 and 90")
+	}
+	if c.Longitude < -180 || c.Longitude > 180 {
+		v.add(field+".Longitude", ValidationCodeOutOfRange, "must be between -180 and 180")
+	}
+}
+
+// custom 校验自定义消息元素
+func (v *validator) custom(field string, c *types.MsgCustomContent) {
+	v.required(field+".Data", c.Data)
+}
+
+// sound 校验语音消息元素
+func (v *validator) sound(field string, c *types.MsgSoundContent) {
+	v.required(field+".UUID", c.UUID)
+	v.required(field+".Url", c.Url)
+	v.downloadFlag(field+".Download_Flag", c.DownloadFlag)
+}
+
+// image 校验图像消息元素
+func (v *validator) image(field string, c *types.MsgImageContent) {
+	v.required(field+".UUID", c.UUID)
+
+	switch c.ImageFormat {
+	case enum.ImageFormatJPG, enum.ImageFormatGIF, enum.ImageFormatPNG, enum.ImageFormatBMP, enum.ImageFormatOTHER:
+	default:
+		v.add(field+".ImageFormat", ValidationCodeInvalid, fmt.Sprintf("unsupported image format %d", c.ImageFormat))
+	}
+
+	if len(c.ImageInfos) == 0 {
+		v.add(field+".ImageInfoArray", ValidationCodeRequired, "is required")
+		return
+	}
+
+	for i, info := range c.ImageInfos {
+		f := fmt.Sprintf("%s.ImageInfoArray[%d]", field, i)
+		if info == nil {
+			v.add(f, ValidationCodeRequired, "image info is nil")
+			continue
+		}
+
+		switch info.Type {
+		case enum.ImageTypeOriginal, enum.ImageTypePic, enum.ImageTypeThumb:
+		default:
+			v.add(f+".Type", ValidationCodeInvalid, fmt.Sprintf("unsupported image type %d", info.Type))
+		}
+
+		v.required(f+".URL", info.Url)
+	}
+}
+
+// file 校验文件消息元素
+func (v *validator) file(field string, c *types.MsgFileContent) {
+	v.required(field+".UUID", c.UUID)
+	v.required(field+".Url", c.Url)
+	v.downloadFlag(field+".Download_Flag", c.DownloadFlag)
+}
+
+// video 校验视频消息元素
+func (v *validator) video(field string, c *types.MsgVideoContent) {
+	v.required(field+".VideoUUID", c.VideoUUID)
+	v.required(field+".VideoUrl", c.VideoUrl)
+	v.required(field+".VideoFormat", c.VideoFormat)
+	v.downloadFlag(field+".VideoDownloadFlag", c.VideoDownloadFlag)
+	v.required(field+".ThumbUUID", c.ThumbUUID)
+	v.required(field+".ThumbUrl", c.ThumbUrl)
+	v.downloadFlag(field+".ThumbDownloadFlag", c.ThumbDownloadFlag)
+}
+
+// relay 校验合并转发消息元素
+func (v *validator) relay(field string, c *types.MsgRelayContent) {
+	v.required(field+".Title", c.Title)
+
+	if len(c.MsgList) == 0 {
+		v.add(field+".MsgList", ValidationCodeRequired, "is required")
+		return
+	}
+
+	if c.MsgNum != len(c.MsgList) {
+		v.add(field+".MsgNum", ValidationCodeInvalid, fmt.Sprintf("must equal the length of MsgList (%d)", len(c.MsgList)))
+	}
+
+	for i, msg := range c.MsgList {
+		f := fmt.Sprintf("%s.MsgList[%d]", field, i)
+		if msg == nil {
+			v.add(f, ValidationCodeRequired, "relay message is nil")
+			continue
+		}
+
+		v.required(f+".From_Account", msg.FromUserId)
+		if len(msg.MsgBody) == 0 {
+			v.add(f+".MsgBody", ValidationCodeRequired, "is required")
+		}
+		v.body(f+".MsgBody", msg.MsgBody)
+	}
+}
+
+// required 校验必填字符串字段
+func (v *validator) required(field, value string) {
+	if value == "" {
+		v.add(field, ValidationCodeRequired, "is required")
+	}
+}
+
+// downloadFlag 校验下载方式标记
+func (v *validator) downloadFlag(field string, flag int) {
+	if flag != downloadFlagUrl {
+		v.add(field, ValidationCodeInvalid, fmt.Sprintf("must be %d", downloadFlagUrl))
+	}
+}
+
+// add 添加校验错误
+func (v *validator) add(field string, code ValidationCode, message string) {
+	v.errs = append(v.errs, &ValidationError{Field: field, Code: code, Message: message})
+}
+
+// err 获取校验结果
+func (v *validator) err() error {
+	if len(v.errs) == 0 {
+		return nil
+	}
+
+	return v.errs
+}
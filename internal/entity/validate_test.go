@@ -0,0 +1,139 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 消息发送前校验单元测试
+ */
+
+package entity
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/d60-Lab/tencent-im/internal/types"
+)
+
+func TestMessage_Validate(t *testing.T) {
+	image := &types.ImageInfo{Type: 1, Size: 1, Width: 1, Height: 1, Url: "https://a"}
+
+	tests := []struct {
+		name     string
+		lifeTime int
+		content  []interface{}
+		want     map[string]ValidationCode
+	}{
+		{
+			name:    "valid",
+			content: []interface{}{types.MsgTextContent{Text: "hi"}, &types.MsgImageContent{UUID: "u", ImageFormat: 1, ImageInfos: []*types.ImageInfo{image}}},
+		},
+		{
+			name: "empty body",
+			want: map[string]ValidationCode{"MsgBody": ValidationCodeRequired},
+		},
+		{
+			name:     "life time out of range",
+			lifeTime: MaxMsgLifeTime + 1,
+			content:  []interface{}{&types.MsgTextContent{Text: "hi"}},
+			want:     map[string]ValidationCode{"MsgLifeTime": ValidationCodeOutOfRange},
+		},
+		{
+			name:    "image without uuid and infos",
+			content: []interface{}{&types.MsgImageContent{ImageFormat: 3}},
+			want: map[string]ValidationCode{
+				"MsgBody[0].MsgContent.UUID":           ValidationCodeRequired,
+				"MsgBody[0].MsgContent.ImageInfoArray": ValidationCodeRequired,
+			},
+		},
+		{
+			name: "sound and file download flag",
+			content: []interface{}{
+				&types.MsgSoundContent{UUID: "s", Url: "https://s", DownloadFlag: 1},
+				types.MsgFileContent{UUID: "f", Url: "https://f"},
+			},
+			want: map[string]ValidationCode{
+				"MsgBody[0].MsgContent.Download_Flag": ValidationCodeInvalid,
+				"MsgBody[1].MsgContent.Download_Flag": ValidationCodeInvalid,
+			},
+		},
+		{
+			name:    "unknown content",
+			content: []interface{}{struct{}{}},
+			want:    map[string]ValidationCode{"MsgBody[0].MsgType": ValidationCodeInvalid},
+		},
+		{
+			name:    "too large",
+			content: []interface{}{&types.MsgTextContent{Text: strings.Repeat("a", MaxMsgSize)}},
+			want:    map[string]ValidationCode{"MsgBody": ValidationCodeTooLarge},
+		},
+		{
+			name:    "text too large",
+			content: []interface{}{types.MsgTextContent{Text: strings.Repeat("中", MaxMsgTextSize/3+1)}},
+			want: map[string]ValidationCode{
+				"MsgBody":                    ValidationCodeTooLarge,
+				"MsgBody[0].MsgContent.Text": ValidationCodeTooLarge,
+			},
+		},
+		{
+			name: "relay",
+			content: []interface{}{&types.MsgRelayContent{
+				Title:   "chat history",
+				MsgNum:  2,
+				MsgList: []*types.RelayMessage{{FromUserId: "user1", MsgBody: []*types.MsgBody{{MsgType: "TIMTextElem", MsgContent: &types.MsgTextContent{}}}}},
+			}},
+			want: map[string]ValidationCode{
+				"MsgBody[0].MsgContent.MsgNum":                                ValidationCodeInvalid,
+				"MsgBody[0].MsgContent.MsgList[0].MsgBody[0].MsgContent.Text": ValidationCodeRequired,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &Message{}
+			m.SetLifeTime(tt.lifeTime)
+			m.AddContent(tt.content...)
+
+			err := m.Validate()
+			if len(tt.want) == 0 {
+				if err != nil {
+					t.Fatalf("Validate() error = %v, want nil", err)
+				}
+				return
+			}
+
+			var errs ValidationErrors
+			if !errors.As(err, &errs) {
+				t.Fatalf("Validate() error = %v, want ValidationErrors", err)
+			}
+
+			got := make(map[string]ValidationCode, len(errs))
+			for _, e := range errs {
+				got[e.Field] = e.Code
+			}
+
+			if len(got) != len(tt.want) {
+				t.Fatalf("Validate() = %v, want %v", got, tt.want)
+			}
+			for field, code := range tt.want {
+				if got[field] != code {
+					t.Errorf("%s = %q, want %q", field, got[field], code)
+				}
+			}
+		})
+	}
+}
+
+func TestMessage_Size(t *testing.T) {
+	m := &Message{}
+	m.AddContent(&types.MsgTextContent{Text: "hi"})
+	m.SetCustomData(strings.Repeat(`"`, 7000))
+
+	size, err := m.Size()
+	if err != nil {
+		t.Fatalf("Size() error = %v", err)
+	}
+	if size <= MaxMsgSize {
+		t.Errorf("Size() = %d, want escaped custom data to exceed %d", size, MaxMsgSize)
+	}
+}
@@ -13,14 +13,15 @@ import (
 
 const (
 	// 消息类型
-	MsgText     = "TIMTextElem"      // 消息元素
-	MsgLocation = "TIMLocationElem"  // 地理位置消息元素
-	MsgFace     = "TIMFaceElem"      // 表情消息元素
-	MsgCustom   = "TIMCustomElem"    // 自定义消息元素
-	MsgSound    = "TIMSoundElem"     // 语音消息元素
-	MsgImage    = "TIMImageElem"     // 图像消息元素
-	MsgFile     = "TIMFileElem"      // 文件消息元素
-	MsgVideo    = "TIMVideoFileElem" // 视频消息元素
+	MsgText     = types.MsgTypeText     // 消息元素
+	MsgLocation = types.MsgTypeLocation // 地理位置消息元素
+	MsgFace     = types.MsgTypeFace     // 表情消息元素
+	MsgCustom   = types.MsgTypeCustom   // 自定义消息元素
+	MsgSound    = types.MsgTypeSound    // 语音消息元素
+	MsgImage    = types.MsgTypeImage    // 图像消息元素
+	MsgFile     = types.MsgTypeFile     // 文件消息元素
+	MsgVideo    = types.MsgTypeVideo    // 视频消息元素
+	MsgRelay    = types.MsgTypeRelay    // 合并转发消息元素
 
 	// 图片格式
 	ImageFormatJPG   = 1   // JPG格式
@@ -92,4 +93,39 @@ const (
 	// iOS10的推送扩展开关
 	MutableContentNormal types.MutableContent = 0 // 关闭iOS10的推送扩展
 	MutableContentEnable types.MutableContent = 1 // 开启iOS10的推送扩展
+
+	// 荣耀推送通知消息分类
+	HonorImportanceLow    types.HonorImportance = "LOW"    // LOW类消息
+	HonorImportanceNormal types.HonorImportance = "NORMAL" // NORMAL类消息
+
+	// OPPO通知栏消息提醒等级
+	OPPONotifyLevelBar        types.OPPONotifyLevel = 1  // 通知栏
+	OPPONotifyLevelLockScreen types.OPPONotifyLevel = 2  // 通知栏、锁屏
+	OPPONotifyLevelFull       types.OPPONotifyLevel = 16 // 通知栏、锁屏、横幅、震动、铃声
+
+	// FCM推送消息类型
+	FCMPushTypeNotification types.FCMPushType = 0 // 通知消息
+	FCMPushTypeData         types.FCMPushType = 1 // 数据消息
+
+	// IOS通知中断级别
+	InterruptionLevelPassive       types.InterruptionLevel = "passive"        // 静默展示，不点亮屏幕
+	InterruptionLevelActive        types.InterruptionLevel = "active"         // 默认级别
+	InterruptionLevelTimeSensitive types.InterruptionLevel = "time-sensitive" // 时效性通知，可突破专注模式
+	InterruptionLevelCritical      types.InterruptionLevel = "critical"       // 关键通知，需申请特殊权限
+
+	// 离线推送厂商通道
+	PushVendorHuaWei types.PushVendor = "HuaWei" // 华为
+	PushVendorHonor  types.PushVendor = "Honor"  // 荣耀
+	PushVendorXiaoMi types.PushVendor = "XiaoMi" // 小米
+	PushVendorOPPO   types.PushVendor = "OPPO"   // OPPO
+	PushVendorVIVO   types.PushVendor = "VIVO"   // VIVO
+	PushVendorMeiZu  types.PushVendor = "MeiZu"  // 魅族
+	PushVendorFCM    types.PushVendor = "FCM"    // Google FCM
+	PushVendorTPNS   types.PushVendor = "TPNS"   // 腾讯移动推送TPNS
+	PushVendorAPNs   types.PushVendor = "APNs"   // Apple APNs
+
+	// 离线推送优先级
+	PushPriorityLow    types.PushPriority = 1 // 低优先级，如运营类消息
+	PushPriorityNormal types.PushPriority = 2 // 普通优先级
+	PushPriorityHigh   types.PushPriority = 3 // 高优先级，如即时通讯及时效性消息
 )
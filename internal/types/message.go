@@ -0,0 +1,76 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 消息体解码实现
+ */
+
+package types
+
+import "encoding/json"
+
+// 消息类型（enum 包依赖本包，消息类型在此定义并由 enum.MsgText 等引用）
+const (
+	MsgTypeText     = "TIMTextElem"      // 消息元素
+	MsgTypeLocation = "TIMLocationElem"  // 地理位置消息元素
+	MsgTypeFace     = "TIMFaceElem"      // 表情消息元素
+	MsgTypeCustom   = "TIMCustomElem"    // 自定义消息元素
+	MsgTypeSound    = "TIMSoundElem"     // 语音消息元素
+	MsgTypeImage    = "TIMImageElem"     // 图像消息元素
+	MsgTypeFile     = "TIMFileElem"      // 文件消息元素
+	MsgTypeVideo    = "TIMVideoFileElem" // 视频消息元素
+	MsgTypeRelay    = "TIMRelayElem"     // 合并转发消息元素
+)
+
+// UnmarshalJSON 按消息类型将消息内容解码为对应的消息元素
+// TIMTextElem 等已知类型解码为 *MsgTextContent 等结构体指针，未知类型保留为 json.RawMessage
+func (m *MsgBody) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		MsgType    string          `json:"MsgType"`
+		MsgContent json.RawMessage `json:"MsgContent"`
+	}
+
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+
+	m.MsgType = raw.MsgType
+
+	var content interface{}
+	switch raw.MsgType {
+	case MsgTypeText:
+		content = &MsgTextContent{}
+	case MsgTypeLocation:
+		content = &MsgLocationContent{}
+	case MsgTypeFace:
+		content = &MsgFaceContent{}
+	case MsgTypeCustom:
+		content = &MsgCustomContent{}
+	case MsgTypeSound:
+		content = &MsgSoundContent{}
+	case MsgTypeImage:
+		content = &MsgImageContent{}
+	case MsgTypeFile:
+		content = &MsgFileContent{}
+	case MsgTypeVideo:
+		content = &MsgVideoContent{}
+	case MsgTypeRelay:
+		content = &MsgRelayContent{}
+	default:
+		if len(raw.MsgContent) > 0 {
+			m.MsgContent = raw.MsgContent
+		} else {
+			m.MsgContent = nil
+		}
+		return nil
+	}
+
+	if len(raw.MsgContent) > 0 && string(raw.MsgContent) != "null" {
+		if err := json.Unmarshal(raw.MsgContent, content); err != nil {
+			return err
+		}
+	}
+
+	m.MsgContent = content
+
+	return nil
+}
@@ -0,0 +1,112 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 消息体解码单元测试
+ */
+
+package types
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestMsgBody_UnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want interface{}
+	}{
+		{
+			name: "text",
+			data: `{"MsgType":"TIMTextElem","MsgContent":{"Text":"hello"}}`,
+			want: &MsgTextContent{Text: "hello"},
+		},
+		{
+			name: "image",
+			data: `{"MsgType":"TIMImageElem","MsgContent":{"UUID":"u1","ImageFormat":1,"ImageInfoArray":[{"Type":1,"Size":10,"Width":2,"Height":3,"URL":"https://a"}]}}`,
+			want: &MsgImageContent{UUID: "u1", ImageFormat: 1, ImageInfos: []*ImageInfo{{Type: 1, Size: 10, Width: 2, Height: 3, Url: "https://a"}}},
+		},
+		{
+			name: "sound",
+			data: `{"MsgType":"TIMSoundElem","MsgContent":{"UUID":"s1","Url":"https://s","Size":1,"Second":2,"Download_Flag":2}}`,
+			want: &MsgSoundContent{UUID: "s1", Url: "https://s", Size: 1, Second: 2, DownloadFlag: 2},
+		},
+		{
+			name: "file",
+			data: `{"MsgType":"TIMFileElem","MsgContent":{"Url":"https://f","UUID":"f1","FileSize":9,"FileName":"a.txt","Download_Flag":2}}`,
+			want: &MsgFileContent{Url: "https://f", UUID: "f1", FileSize: 9, FileName: "a.txt", DownloadFlag: 2},
+		},
+		{
+			name: "video",
+			data: `{"MsgType":"TIMVideoFileElem","MsgContent":{"VideoUUID":"v1","VideoSecond":5,"VideoFormat":"mp4"}}`,
+			want: &MsgVideoContent{VideoUUID: "v1", VideoSecond: 5, VideoFormat: "mp4"},
+		},
+		{
+			name: "location",
+			data: `{"MsgType":"TIMLocationElem","MsgContent":{"Desc":"here","Latitude":1.5,"Longitude":2.5}}`,
+			want: &MsgLocationContent{Desc: "here", Latitude: 1.5, Longitude: 2.5},
+		},
+		{
+			name: "face",
+			data: `{"MsgType":"TIMFaceElem","MsgContent":{"Index":1,"Data":"smile"}}`,
+			want: &MsgFaceContent{Index: 1, Data: "smile"},
+		},
+		{
+			name: "custom",
+			data: `{"MsgType":"TIMCustomElem","MsgContent":{"Data":"d","Desc":"desc","Ext":"e","Sound":"s"}}`,
+			want: &MsgCustomContent{Data: "d", Desc: "desc", Ext: "e", Sound: "s"},
+		},
+		{
+			name: "relay",
+			data: `{"MsgType":"TIMRelayElem","MsgContent":{"Title":"chat history","MsgNum":1,"CompatibleText":"upgrade","AbstractList":["user1: hi"],"MsgList":[{"From_Account":"user1","MsgTimeStamp":1700000000,"MsgBody":[{"MsgType":"TIMTextElem","MsgContent":{"Text":"hi"}}]}]}}`,
+			want: &MsgRelayContent{
+				Title:          "chat history",
+				MsgNum:         1,
+				CompatibleText: "upgrade",
+				AbstractList:   []string{"user1: hi"},
+				MsgList: []*RelayMessage{{
+					FromUserId:   "user1",
+					MsgTimeStamp: 1700000000,
+					MsgBody:      []*MsgBody{{MsgType: MsgTypeText, MsgContent: &MsgTextContent{Text: "hi"}}},
+				}},
+			},
+		},
+		{
+			name: "unknown type keeps raw content",
+			data: `{"MsgType":"TIMNewElem","MsgContent":{"Foo":"bar"}}`,
+			want: json.RawMessage(`{"Foo":"bar"}`),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			body := &MsgBody{}
+			if err := json.Unmarshal([]byte(tt.data), body); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if !reflect.DeepEqual(body.MsgContent, tt.want) {
+				t.Errorf("MsgContent = %#v, want %#v", body.MsgContent, tt.want)
+			}
+		})
+	}
+}
+
+func TestMsgBody_RoundTrip(t *testing.T) {
+	data := `[{"MsgType":"TIMTextElem","MsgContent":{"Text":"hi"}},{"MsgType":"TIMNewElem","MsgContent":{"Foo":"bar"}}]`
+
+	var bodies []*MsgBody
+	if err := json.Unmarshal([]byte(data), &bodies); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	b, err := json.Marshal(bodies)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	if string(b) != data {
+		t.Errorf("Marshal() = %s, want %s", b, data)
+	}
+}
@@ -29,15 +29,30 @@ type (
 		VIVOClassification     int    `json:"VIVOClassification,omitempty"`     // （选填）VIVO 手机推送消息分类，“0”代表运营消息，“1”代表系统消息，不填默认为1。
 		HuaWeiImportance       string `json:"HuaWeiImportance,omitempty"`       // （选填）华为推送通知消息分类，取值为 LOW、NORMAL，不填默认为 NORMAL。
 		ExtAsHuaweiIntentParam int    `json:"ExtAsHuaweiIntentParam,omitempty"` // （选填）在控制台配置华为推送为“打开应用内指定页面”的前提下，传“1”表示将透传内容 Ext 作为 Intent 的参数，“0”表示将透传内容 Ext 作为 Action 参数。不填默认为0。两种传参区别可参见 华为推送文档。
+		HuaWeiCategory         string `json:"HuaWeiCategory,omitempty"`         // （选填）华为推送通知消息的场景标识，如 IM、VOIP，设置后消息分类须为 NORMAL。
+		HuaWeiImage            string `json:"HuaWeiImage,omitempty"`            // （选填）华为推送通知栏消息右侧小图标的地址，须为 HTTPS 地址。
+		HonorImportance        string `json:"HonorImportance,omitempty"`        // （选填）荣耀推送通知消息分类，取值为 LOW、NORMAL，不填默认为 NORMAL。
+		HonorImage             string `json:"HonorImage,omitempty"`             // （选填）荣耀推送通知栏消息右侧小图标的地址，须为 HTTPS 地址。
+		MeiZuChannelID         string `json:"MeiZuChannelID,omitempty"`         // （选填）魅族手机 Flyme 的通知渠道字段。
+		VIVOCategory           string `json:"VIVOCategory,omitempty"`           // （选填）VIVO 手机推送消息的二级分类，如 IM、ACCOUNT，须与 VIVOClassification 一致。
+		OPPOCategory           string `json:"OPPOCategory,omitempty"`           // （选填）OPPO 手机推送消息的分类，如 IM、ACCOUNT。
+		OPPONotifyLevel        int    `json:"OPPONotifyLevel,omitempty"`        // （选填）OPPO 手机通知栏消息的提醒等级，1表示通知栏，2表示通知栏及锁屏，16表示通知栏、锁屏、横幅、震动及铃声。
+		GoogleImage            string `json:"GoogleImage,omitempty"`            // （选填）Google 推送通知栏消息的图片地址。
+		FCMPushType            int    `json:"FCMPushType,omitempty"`            // （选填）FCM 推送消息类型，0表示通知消息，1表示数据消息（不展示通知栏，由应用自行处理），不填默认为0。
+		TPNSChannelID          string `json:"TPNSChannelID,omitempty"`          // （选填）TPNS 推送的通知渠道字段。
+		TPNSImage              string `json:"TPNSImage,omitempty"`              // （选填）TPNS 推送通知栏消息的图片地址。
 	}
 
 	// ApnsInfo IOS离线推送消息
 	ApnsInfo struct {
-		BadgeMode      int    `json:"BadgeMode,omitempty"`      // （选填）这个字段缺省或者为0表示需要计数，为1表示本条消息不需要计数，即右上角图标数字不增加。
-		Title          string `json:"Title,omitempty"`          // （选填）该字段用于标识 APNs 推送的标题，若填写则会覆盖最上层 Title。
-		SubTitle       string `json:"SubTitle,omitempty"`       // （选填）该字段用于标识 APNs 推送的子标题。
-		Image          string `json:"Image,omitempty"`          // （选填）该字段用于标识 APNs 携带的图片地址，当客户端拿到该字段时，可以通过下载图片资源的方式将图片展示在弹窗上。
-		MutableContent int    `json:"MutableContent,omitempty"` // （选填）为1表示开启 iOS 10 的推送扩展，默认为0。
+		BadgeMode         int    `json:"BadgeMode,omitempty"`         // （选填）这个字段缺省或者为0表示需要计数，为1表示本条消息不需要计数，即右上角图标数字不增加。
+		Title             string `json:"Title,omitempty"`             // （选填）该字段用于标识 APNs 推送的标题，若填写则会覆盖最上层 Title。
+		SubTitle          string `json:"SubTitle,omitempty"`          // （选填）该字段用于标识 APNs 推送的子标题。
+		Image             string `json:"Image,omitempty"`             // （选填）该字段用于标识 APNs 携带的图片地址，当客户端拿到该字段时，可以通过下载图片资源的方式将图片展示在弹窗上。
+		MutableContent    int    `json:"MutableContent,omitempty"`    // （选填）为1表示开启 iOS 10 的推送扩展，默认为0。
+		Sound             string `json:"Sound,omitempty"`             // （选填）APNs 推送的声音文件名。
+		InterruptionLevel string `json:"InterruptionLevel,omitempty"` // （选填）iOS 15 及以上的通知中断级别，取值为 passive、active、time-sensitive、critical。
+		ThreadId          string `json:"ThreadId,omitempty"`          // （选填）APNs 推送的通知分组标识，相同标识的通知将归为一组展示。
 	}
 
 	// OfflinePushInfo 离线推送消息
@@ -130,6 +145,26 @@ type (
 		ThumbDownloadFlag int    `json:"ThumbDownloadFlag"` // （必填）视频缩略图下载方式标记。目前 ThumbDownloadFlag 取值只能为2，表示可通过ThumbUrl字段值的 URL 地址直接下载视频缩略图。
 	}
 
+	// MsgRelayContent 合并转发消息元素
+	MsgRelayContent struct {
+		Title          string          `json:"Title"`          // （必填）合并转发消息的标题，如“群聊的聊天记录”
+		MsgNum         int             `json:"MsgNum"`         // （必填）被转发的消息条数
+		CompatibleText string          `json:"CompatibleText"` // （必填）兼容文本，不支持合并转发消息的旧版本客户端将展示该文本
+		AbstractList   []string        `json:"AbstractList"`   // （必填）消息摘要列表，如 ["user1: hello", "user2: hi"]
+		MsgList        []*RelayMessage `json:"MsgList"`        // （必填）被转发的消息列表
+	}
+
+	// RelayMessage 被合并转发的消息
+	RelayMessage struct {
+		FromUserId      string     `json:"From_Account"`              // （必填）消息发送方UserID
+		GroupId         string     `json:"GroupId,omitempty"`         // （选填）群聊消息的群ID，单聊消息为空
+		MsgSeq          int        `json:"MsgSeq,omitempty"`          // （选填）消息序列号
+		MsgRandom       uint32     `json:"MsgRandom,omitempty"`       // （选填）消息随机数
+		MsgTimeStamp    int64      `json:"MsgTimeStamp"`              // （必填）消息时间戳，UNIX 时间戳（单位：秒）
+		CloudCustomData string     `json:"CloudCustomData,omitempty"` // （选填）消息自定义数据
+		MsgBody         []*MsgBody `json:"MsgBody"`                   // （必填）消息内容
+	}
+
 	// ImageInfo 图片下载信息
 	ImageInfo struct {
 		Type   int    `json:"Type"`   // （必填）图片类型： 1-原图，2-大图，3-缩略图。
@@ -168,4 +203,22 @@ type (
 
 	// MutableContent IOS10的推送扩展开关
 	MutableContent int
+
+	// HonorImportance 荣耀推送通知消息分类
+	HonorImportance string
+
+	// OPPONotifyLevel OPPO通知栏消息提醒等级
+	OPPONotifyLevel int
+
+	// FCMPushType FCM推送消息类型
+	FCMPushType int
+
+	// InterruptionLevel IOS通知中断级别
+	InterruptionLevel string
+
+	// PushVendor 离线推送厂商通道
+	PushVendor string
+
+	// PushPriority 离线推送优先级
+	PushPriority int
 )
@@ -0,0 +1,270 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 消息构建器
+ */
+
+package message
+
+import (
+	"hash/fnv"
+	"math/rand"
+
+	"github.com/d60-Lab/tencent-im/group"
+	"github.com/d60-Lab/tencent-im/internal/enum"
+	"github.com/d60-Lab/tencent-im/internal/types"
+	"github.com/d60-Lab/tencent-im/private"
+	"github.com/d60-Lab/tencent-im/push"
+)
+
+const downloadFlagUrl = 2 // 可通过 URL 地址直接下载
+
+const (
+	// 图片格式
+	ImageFormatJPG   ImageFormat = enum.ImageFormatJPG   // JPG格式
+	ImageFormatGIF   ImageFormat = enum.ImageFormatGIF   // GIF格式
+	ImageFormatPNG   ImageFormat = enum.ImageFormatPNG   // PNG格式
+	ImageFormatBMP   ImageFormat = enum.ImageFormatBMP   // BMP格式
+	ImageFormatOther ImageFormat = enum.ImageFormatOTHER // 其他格式
+
+	// 图片类型
+	ImageTypeOriginal ImageType = enum.ImageTypeOriginal // 原图
+	ImageTypeLarge    ImageType = enum.ImageTypePic      // 大图
+	ImageTypeThumb    ImageType = enum.ImageTypeThumb    // 缩略图
+)
+
+type (
+	// ImageFormat 图片格式
+	ImageFormat int
+
+	// ImageType 图片类型
+	ImageType int
+
+	// 消息元素类型别名，便于在包外构建合并转发等消息元素
+	MsgBody         = types.MsgBody
+	MsgRelayContent = types.MsgRelayContent
+	RelayMessage    = types.RelayMessage
+
+	// Target 消息构建目标，private.Message、group.Message 及 push.Message 均实现了该接口
+	Target interface {
+		SetSender(userId string)
+		SetLifeTime(lifeTime int)
+		SetRandom(random uint32)
+		SetContent(msgContent ...interface{})
+		SetCustomData(data interface{})
+	}
+
+	// atTarget 支持@成员的消息构建目标
+	atTarget interface {
+		AtMembers(userId ...string)
+	}
+
+	// Builder 消息构建器
+	// 同一构建器生成的单聊、群聊及推送消息具有相同的消息体、消息随机数及自定义数据
+	Builder struct {
+		sender     string
+		lifeTime   int
+		random     uint32
+		body       []*types.MsgBody
+		customData interface{}
+		atMembers  []string
+	}
+)
+
+// NewBuilder 新建消息构建器（消息随机数在新建时生成，可通过 Random 或 IdempotencyKey 覆盖）
+func NewBuilder() *Builder {
+	return &Builder{random: rand.Uint32()}
+}
+
+// Sender 设置发送方UserId
+func (b *Builder) Sender(userId string) *Builder {
+	b.sender = userId
+	return b
+}
+
+// LifeTime 设置消息离线保存时长（单位：秒），最长为7天（604800秒）
+func (b *Builder) LifeTime(lifeTime int) *Builder {
+	b.lifeTime = lifeTime
+	return b
+}
+
+// Random 设置消息随机数
+func (b *Builder) Random(random uint32) *Builder {
+	b.random = random
+	return b
+}
+
+// IdempotencyKey 根据幂等键生成消息随机数，相同幂等键的消息重试时使用相同的随机数，便于后台去重
+func (b *Builder) IdempotencyKey(key string) *Builder {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+
+	b.random = h.Sum32()
+	if b.random == 0 {
+		b.random = 1
+	}
+
+	return b
+}
+
+// CloudCustomData 设置消息自定义数据（云端保存，会发送到对端，程序卸载重装后还能拉取到）
+func (b *Builder) CloudCustomData(data interface{}) *Builder {
+	b.customData = data
+	return b
+}
+
+// Text 添加文本消息元素
+func (b *Builder) Text(text string) *Builder {
+	return b.add(enum.MsgText, &types.MsgTextContent{Text: text})
+}
+
+// At @群成员（仅对群聊消息有效，文本内容中需自行包含@昵称）
+func (b *Builder) At(userId ...string) *Builder {
+	b.atMembers = append(b.atMembers, userId...)
+	return b
+}
+
+// AtAll @所有成员（仅对群聊消息有效）
+func (b *Builder) AtAll() *Builder {
+	return b.At(group.AtAllMembersFlag)
+}
+
+// Image 添加图像消息元素，images 为原图、大图及缩略图的下载信息
+func (b *Builder) Image(uuid string, format ImageFormat, images ...*types.ImageInfo) *Builder {
+	return b.add(enum.MsgImage, &types.MsgImageContent{
+		UUID:        uuid,
+		ImageFormat: int(format),
+		ImageInfos:  images,
+	})
+}
+
+// Sound 添加语音消息元素
+func (b *Builder) Sound(uuid, url string, size, second int) *Builder {
+	return b.add(enum.MsgSound, &types.MsgSoundContent{
+		UUID:         uuid,
+		Url:          url,
+		Size:         size,
+		Second:       second,
+		DownloadFlag: downloadFlagUrl,
+	})
+}
+
+// Video 添加视频消息元素，未设置下载方式标记时默认为通过 URL 地址直接下载
+func (b *Builder) Video(video types.MsgVideoContent) *Builder {
+	if video.VideoDownloadFlag == 0 {
+		video.VideoDownloadFlag = downloadFlagUrl
+	}
+	if video.ThumbDownloadFlag == 0 {
+		video.ThumbDownloadFlag = downloadFlagUrl
+	}
+
+	return b.add(enum.MsgVideo, &video)
+}
+
+// File 添加文件消息元素
+func (b *Builder) File(uuid, url, fileName string, fileSize int) *Builder {
+	return b.add(enum.MsgFile, &types.MsgFileContent{
+		Url:          url,
+		UUID:         uuid,
+		FileSize:     fileSize,
+		FileName:     fileName,
+		DownloadFlag: downloadFlagUrl,
+	})
+}
+
+// Location 添加地理位置消息元素
+func (b *Builder) Location(desc string, latitude, longitude float64) *Builder {
+	return b.add(enum.MsgLocation, &types.MsgLocationContent{
+		Desc:      desc,
+		Latitude:  latitude,
+		Longitude: longitude,
+	})
+}
+
+// Face 添加表情消息元素
+func (b *Builder) Face(index int, data string) *Builder {
+	return b.add(enum.MsgFace, &types.MsgFaceContent{Index: index, Data: data})
+}
+
+// Custom 添加自定义消息元素
+func (b *Builder) Custom(content types.MsgCustomContent) *Builder {
+	return b.add(enum.MsgCustom, &content)
+}
+
+// Relay 添加合并转发消息元素，未设置消息条数时默认为消息列表的长度
+func (b *Builder) Relay(content types.MsgRelayContent) *Builder {
+	if content.MsgNum == 0 {
+		content.MsgNum = len(content.MsgList)
+	}
+
+	return b.add(enum.MsgRelay, &content)
+}
+
+// Body 获取消息体
+func (b *Builder) Body() []*types.MsgBody {
+	body := make([]*types.MsgBody, len(b.body))
+	copy(body, b.body)
+
+	return body
+}
+
+// Apply 将构建器内容应用至消息（会覆盖消息原有的内容）
+func (b *Builder) Apply(m Target) {
+	m.SetSender(b.sender)
+	m.SetLifeTime(b.lifeTime)
+	m.SetRandom(b.random)
+	m.SetCustomData(b.customData)
+
+	body := b.Body()
+	contents := make([]interface{}, 0, len(body))
+	for _, item := range body {
+		contents = append(contents, item)
+	}
+	m.SetContent(contents...)
+
+	if t, ok := m.(atTarget); ok && len(b.atMembers) > 0 {
+		t.AtMembers(b.atMembers...)
+	}
+}
+
+// PrivateMessage 构建单聊消息
+func (b *Builder) PrivateMessage(receivers ...string) *private.Message {
+	m := private.NewMessage()
+	b.Apply(m)
+	m.SetReceivers(receivers...)
+
+	return m
+}
+
+// GroupMessage 构建群聊消息
+func (b *Builder) GroupMessage() *group.Message {
+	m := group.NewMessage()
+	b.Apply(m)
+
+	return m
+}
+
+// PushMessage 构建推送消息
+func (b *Builder) PushMessage() *push.Message {
+	m := push.NewMessage()
+	b.Apply(m)
+
+	return m
+}
+
+// NewImageInfo 新建图片下载信息
+func NewImageInfo(imageType ImageType, url string, size, width, height int) *types.ImageInfo {
+	return &types.ImageInfo{
+		Type:   int(imageType),
+		Size:   size,
+		Width:  width,
+		Height: height,
+		Url:    url,
+	}
+}
+
+// add 添加消息元素
+func (b *Builder) add(msgType string, content interface{}) *Builder {
+	b.body = append(b.body, &types.MsgBody{MsgType: msgType, MsgContent: content})
+	return b
+}
@@ -0,0 +1,99 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 消息构建器单元测试
+ */
+
+package message
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/d60-Lab/tencent-im/internal/types"
+)
+
+func TestBuilder_IdenticalPayloads(t *testing.T) {
+	b := NewBuilder().
+		Sender("user1").
+		IdempotencyKey("order-1").
+		CloudCustomData(map[string]int{"order": 1}).
+		Text("@user2 hello").
+		At("user2").
+		Image("img1", ImageFormatPNG, NewImageInfo(ImageTypeOriginal, "https://a", 100, 10, 10), NewImageInfo(ImageTypeThumb, "https://t", 10, 1, 1)).
+		Sound("s1", "https://s", 10, 2).
+		Video(types.MsgVideoContent{VideoUUID: "v1", VideoUrl: "https://v"}).
+		File("f1", "https://f", "a.txt", 3).
+		Location("here", 1.5, 2.5).
+		Face(1, "smile").
+		Custom(types.MsgCustomContent{Data: "d"})
+
+	p := b.PrivateMessage("user2")
+	g := b.GroupMessage()
+	m := b.PushMessage()
+
+	marshal := func(v interface{}) string {
+		data, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(data)
+	}
+
+	want := marshal(b.Body())
+	for name, body := range map[string][]*types.MsgBody{"private": p.GetBody(), "group": g.GetBody(), "push": m.GetBody()} {
+		if got := marshal(body); got != want {
+			t.Errorf("%s body = %s, want %s", name, got, want)
+		}
+	}
+
+	if len(p.GetBody()) != 8 {
+		t.Fatalf("body length = %d, want 8", len(p.GetBody()))
+	}
+	for _, item := range p.GetBody() {
+		if item.MsgType == "" {
+			t.Errorf("MsgType is empty for %#v", item.MsgContent)
+		}
+	}
+
+	if p.GetRandom() != g.GetRandom() || g.GetRandom() != m.GetRandom() {
+		t.Errorf("random = %d/%d/%d, want identical", p.GetRandom(), g.GetRandom(), m.GetRandom())
+	}
+	if NewBuilder().IdempotencyKey("order-1").PushMessage().GetRandom() != p.GetRandom() {
+		t.Error("random differs for the same idempotency key")
+	}
+	if nb := NewBuilder().Text("hi"); nb.PrivateMessage("user2").GetRandom() != nb.GroupMessage().GetRandom() {
+		t.Error("random differs between applies of the same builder")
+	}
+	if marshal(p.GetCustomData()) != marshal(m.GetCustomData()) {
+		t.Errorf("custom data = %v/%v, want identical", p.GetCustomData(), m.GetCustomData())
+	}
+	if p.GetSender() != "user1" || p.GetLastReceiver() != "user2" {
+		t.Errorf("sender/receiver = %s/%s", p.GetSender(), p.GetLastReceiver())
+	}
+}
+
+func TestBuilder_Relay(t *testing.T) {
+	m := NewBuilder().Relay(types.MsgRelayContent{
+		Title:          "chat history",
+		CompatibleText: "upgrade",
+		AbstractList:   []string{"user1: hi"},
+		MsgList: []*types.RelayMessage{{
+			FromUserId:   "user1",
+			MsgTimeStamp: 1700000000,
+			MsgBody:      NewBuilder().Text("hi").Body(),
+		}},
+	}).GroupMessage()
+
+	if err := m.CheckBodyArgError(); err != nil {
+		t.Fatalf("CheckBodyArgError() error = %v", err)
+	}
+
+	body := m.GetBody()
+	if len(body) != 1 || body[0].MsgType != "TIMRelayElem" {
+		t.Fatalf("body = %+v, want a single TIMRelayElem", body)
+	}
+	if content := body[0].MsgContent.(*types.MsgRelayContent); content.MsgNum != 1 {
+		t.Errorf("MsgNum = %d, want 1", content.MsgNum)
+	}
+}
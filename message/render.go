@@ -0,0 +1,319 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 消息渲染器，将消息体渲染为纯文本或 Markdown
+ */
+
+package message
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/d60-Lab/tencent-im/internal/types"
+)
+
+const (
+	FormatText     Format = iota // 纯文本
+	FormatMarkdown               // Markdown
+)
+
+const (
+	defaultImagePlaceholder    = "[Image]"
+	defaultSoundPlaceholder    = "[Voice {second}s]"
+	defaultVideoPlaceholder    = "[Video {second}s]"
+	defaultFilePlaceholder     = "[File: {name}]"
+	defaultFacePlaceholder     = "[Face {index}]"
+	defaultLocationPlaceholder = "[Location: {desc}]"
+	defaultCustomPlaceholder   = "[Custom Message]"
+	defaultRelayPlaceholder    = "[Chat History: {title}]"
+	defaultUnknownPlaceholder  = "[Unsupported Message]"
+	defaultLocationUrl         = "https://maps.google.com/?q={lat},{lng}"
+	defaultSeparator           = " "
+)
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`, "`", "\\`", `*`, `\*`, `_`, `\_`, `[`, `\[`, `]`, `\]`,
+	`<`, `\<`, `>`, `\>`, `#`, `\#`, `|`, `\|`, `~`, `\~`,
+)
+
+type (
+	// Format 渲染格式
+	Format int
+
+	// RenderOptions 渲染配置，占位符中的 {name} 等变量将被替换为消息元素中的对应值
+	RenderOptions struct {
+		ImagePlaceholder    string                                                              // （选填）图像占位符，默认为 [Image]
+		SoundPlaceholder    string                                                              // （选填）语音占位符，支持 {second} {size}，默认为 [Voice {second}s]
+		VideoPlaceholder    string                                                              // （选填）视频占位符，支持 {second} {size} {format}，默认为 [Video {second}s]
+		FilePlaceholder     string                                                              // （选填）文件占位符，支持 {name} {size}，默认为 [File: {name}]
+		FacePlaceholder     string                                                              // （选填）表情占位符，支持 {index} {data}，默认为 [Face {index}]
+		LocationPlaceholder string                                                              // （选填）地理位置占位符，支持 {desc} {lat} {lng}，默认为 [Location: {desc}]
+		CustomPlaceholder   string                                                              // （选填）自定义消息占位符，支持 {desc} {data}，默认优先使用 Desc，Desc 为空时为 [Custom Message]
+		RelayPlaceholder    string                                                              // （选填）合并转发消息占位符，支持 {title} {count}，默认为 [Chat History: {title}]
+		UnknownPlaceholder  string                                                              // （选填）未知消息占位符，默认为 [Unsupported Message]
+		LocationUrl         string                                                              // （选填）地理位置链接，支持 {lat} {lng}，默认为 Google 地图链接，设置为 "-" 时不渲染链接
+		Separator           string                                                              // （选填）消息元素之间的分隔符，默认为空格
+		Custom              func(content *types.MsgCustomContent, format Format) (string, bool) // （选填）自定义消息渲染钩子，返回 false 时使用默认渲染
+	}
+
+	// Renderer 消息渲染器
+	Renderer struct {
+		opt RenderOptions
+	}
+)
+
+var defaultRenderer = NewRenderer()
+
+// NewRenderer 新建消息渲染器
+func NewRenderer(opt ...RenderOptions) *Renderer {
+	o := RenderOptions{}
+	if len(opt) > 0 {
+		o = opt[0]
+	}
+
+	setDefault(&o.ImagePlaceholder, defaultImagePlaceholder)
+	setDefault(&o.SoundPlaceholder, defaultSoundPlaceholder)
+	setDefault(&o.VideoPlaceholder, defaultVideoPlaceholder)
+	setDefault(&o.FilePlaceholder, defaultFilePlaceholder)
+	setDefault(&o.FacePlaceholder, defaultFacePlaceholder)
+	setDefault(&o.LocationPlaceholder, defaultLocationPlaceholder)
+	setDefault(&o.RelayPlaceholder, defaultRelayPlaceholder)
+	setDefault(&o.UnknownPlaceholder, defaultUnknownPlaceholder)
+	setDefault(&o.LocationUrl, defaultLocationUrl)
+	setDefault(&o.Separator, defaultSeparator)
+
+	return &Renderer{opt: o}
+}
+
+// RenderText 使用默认配置将消息体渲染为纯文本
+func RenderText(body []*types.MsgBody) string {
+	return defaultRenderer.Text(body)
+}
+
+// RenderMarkdown 使用默认配置将消息体渲染为 Markdown
+func RenderMarkdown(body []*types.MsgBody) string {
+	return defaultRenderer.Markdown(body)
+}
+
+// Text 将消息体渲染为纯文本
+func (r *Renderer) Text(body []*types.MsgBody) string {
+	return r.Render(body, FormatText)
+}
+
+// Markdown 将消息体渲染为 Markdown，仅渲染 http 及 https 链接，其他链接仅保留文本
+func (r *Renderer) Markdown(body []*types.MsgBody) string {
+	return r.Render(body, FormatMarkdown)
+}
+
+// Render 将消息体渲染为指定格式
+func (r *Renderer) Render(body []*types.MsgBody, format Format) string {
+	parts := make([]string, 0, len(body))
+	for _, item := range body {
+		if item == nil {
+			continue
+		}
+
+		if s := r.element(item.MsgContent, format); s != "" {
+			parts = append(parts, s)
+		}
+	}
+
+	return strings.Join(parts, r.opt.Separator)
+}
+
+// element 渲染消息元素
+func (r *Renderer) element(content interface{}, format Format) string {
+	md := format == FormatMarkdown
+
+	switch c := normalizeContent(content).(type) {
+	case *types.MsgTextContent:
+		if md {
+			return markdownEscaper.Replace(c.Text)
+		}
+		return c.Text
+
+	case *types.MsgFaceContent:
+		return r.escape(placeholder(r.opt.FacePlaceholder, "{index}", strconv.Itoa(c.Index), "{data}", c.Data), md)
+
+	case *types.MsgImageContent:
+		text := r.escape(r.opt.ImagePlaceholder, md)
+		if url, ok := safeUrl(imageUrl(c)); md && ok {
+			return "![" + text + "](" + url + ")"
+		}
+		return text
+
+	case *types.MsgSoundContent:
+		text := placeholder(r.opt.SoundPlaceholder, "{second}", strconv.Itoa(c.Second), "{size}", strconv.Itoa(c.Size))
+		return r.link(text, c.Url, md)
+
+	case *types.MsgVideoContent:
+		text := placeholder(r.opt.VideoPlaceholder, "{second}", strconv.Itoa(c.VideoSecond), "{size}", strconv.Itoa(c.VideoSize), "{format}", c.VideoFormat)
+		return r.link(text, c.VideoUrl, md)
+
+	case *types.MsgFileContent:
+		text := placeholder(r.opt.FilePlaceholder, "{name}", c.FileName, "{size}", strconv.Itoa(c.FileSize))
+		return r.link(text, c.Url, md)
+
+	case *types.MsgLocationContent:
+		lat := strconv.FormatFloat(c.Latitude, 'f', -1, 64)
+		lng := strconv.FormatFloat(c.Longitude, 'f', -1, 64)
+		text := placeholder(r.opt.LocationPlaceholder, "{desc}", c.Desc, "{lat}", lat, "{lng}", lng)
+
+		url := ""
+		if r.opt.LocationUrl != "-" {
+			url = placeholder(r.opt.LocationUrl, "{lat}", lat, "{lng}", lng)
+		}
+		if md {
+			return r.link(text, url, md)
+		}
+		if url != "" {
+			return text + " " + url
+		}
+		return text
+
+	case *types.MsgCustomContent:
+		if r.opt.Custom != nil {
+			if s, ok := r.opt.Custom(c, format); ok {
+				return s
+			}
+		}
+		if r.opt.CustomPlaceholder != "" {
+			return r.escape(placeholder(r.opt.CustomPlaceholder, "{desc}", c.Desc, "{data}", c.Data), md)
+		}
+		if c.Desc != "" {
+			return r.escape(c.Desc, md)
+		}
+		return r.escape(defaultCustomPlaceholder, md)
+
+	case *types.MsgRelayContent:
+		return r.relay(c, md)
+	}
+
+	return r.escape(r.opt.UnknownPlaceholder, md)
+}
+
+// relay 渲染合并转发消息元素，摘要列表逐行展示
+func (r *Renderer) relay(c *types.MsgRelayContent, md bool) string {
+	count := c.MsgNum
+	if count == 0 {
+		count = len(c.MsgList)
+	}
+
+	lines := []string{r.escape(placeholder(r.opt.RelayPlaceholder, "{title}", c.Title, "{count}", strconv.Itoa(count)), md)}
+	for _, abstract := range c.AbstractList {
+		if md {
+			lines = append(lines, "> "+markdownEscaper.Replace(abstract))
+		} else {
+			lines = append(lines, "  "+abstract)
+		}
+	}
+
+	sep := "\n"
+	if md {
+		sep = "\n\n"
+	}
+
+	return strings.Join(lines, sep)
+}
+
+// link 渲染带链接的占位符，链接不安全时仅渲染转义后的文本
+func (r *Renderer) link(text, rawUrl string, md bool) string {
+	if !md {
+		return text
+	}
+
+	text = markdownEscaper.Replace(text)
+	url, ok := safeUrl(rawUrl)
+	if !ok {
+		return text
+	}
+
+	return "[" + text + "](" + url + ")"
+}
+
+// escape 按格式转义文本
+func (r *Renderer) escape(text string, md bool) string {
+	if md {
+		return markdownEscaper.Replace(text)
+	}
+
+	return text
+}
+
+// normalizeContent 将值类型的消息内容转换为指针类型
+func normalizeContent(content interface{}) interface{} {
+	switch c := content.(type) {
+	case types.MsgTextContent:
+		return &c
+	case types.MsgFaceContent:
+		return &c
+	case types.MsgImageContent:
+		return &c
+	case types.MsgSoundContent:
+		return &c
+	case types.MsgVideoContent:
+		return &c
+	case types.MsgFileContent:
+		return &c
+	case types.MsgLocationContent:
+		return &c
+	case types.MsgCustomContent:
+		return &c
+	case types.MsgRelayContent:
+		return &c
+	}
+
+	return content
+}
+
+// imageUrl 获取图像的展示地址，优先使用大图，其次为原图及缩略图
+func imageUrl(c *types.MsgImageContent) string {
+	for _, t := range []ImageType{ImageTypeLarge, ImageTypeOriginal, ImageTypeThumb} {
+		for _, info := range c.ImageInfos {
+			if info != nil && info.Type == int(t) && info.Url != "" {
+				return info.Url
+			}
+		}
+	}
+
+	return ""
+}
+
+// safeUrl 校验并编码 Markdown 链接地址
+// 仅允许 http 及 https 协议，空白字符、控制字符及 ()<> 将被百分号编码，避免闭合链接语法或注入 HTML
+func safeUrl(rawUrl string) (string, bool) {
+	u, err := url.Parse(strings.TrimSpace(rawUrl))
+	if err != nil || u.Host == "" || (!strings.EqualFold(u.Scheme, "http") && !strings.EqualFold(u.Scheme, "https")) {
+		return "", false
+	}
+
+	var b strings.Builder
+	for _, c := range u.String() {
+		if !unicode.IsSpace(c) && !unicode.IsControl(c) && !strings.ContainsRune("()<>", c) {
+			b.WriteRune(c)
+			continue
+		}
+
+		for _, x := range []byte(string(c)) {
+			fmt.Fprintf(&b, "%%%02X", x)
+		}
+	}
+
+	return b.String(), true
+}
+
+// placeholder 替换占位符中的变量
+func placeholder(format string, oldnew ...string) string {
+	return strings.NewReplacer(oldnew...).Replace(format)
+}
+
+// setDefault 为空字符串设置默认值
+func setDefault(s *string, value string) {
+	if *s == "" {
+		*s = value
+	}
+}
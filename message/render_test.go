@@ -0,0 +1,132 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 消息渲染器单元测试
+ */
+
+package message
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/d60-Lab/tencent-im/internal/types"
+)
+
+func TestRenderer(t *testing.T) {
+	body := NewBuilder().
+		Text("see *this*").
+		Image("img1", ImageFormatPNG, NewImageInfo(ImageTypeThumb, "https://t", 1, 1, 1), NewImageInfo(ImageTypeLarge, "https://l", 1, 1, 1)).
+		Sound("s1", "https://s", 10, 3).
+		File("f1", "https://f", "a.txt", 9).
+		Location("Office", 1.5, 2.25).
+		Custom(types.MsgCustomContent{Data: `{"type":"order"}`, Desc: "New order"}).
+		Body()
+	body = append(body, &types.MsgBody{MsgType: "TIMNewElem", MsgContent: json.RawMessage(`{}`)})
+
+	tests := []struct {
+		name   string
+		render func([]*types.MsgBody) string
+		want   string
+	}{
+		{
+			name:   "text",
+			render: RenderText,
+			want:   "see *this* [Image] [Voice 3s] [File: a.txt] [Location: Office] https://maps.google.com/?q=1.5,2.25 New order [Unsupported Message]",
+		},
+		{
+			name:   "markdown",
+			render: RenderMarkdown,
+			want:   `see \*this\* ![\[Image\]](https://l) [\[Voice 3s\]](https://s) [\[File: a.txt\]](https://f) [\[Location: Office\]](https://maps.google.com/?q=1.5,2.25) New order \[Unsupported Message\]`,
+		},
+		{
+			name: "custom placeholders and hook",
+			render: NewRenderer(RenderOptions{
+				ImagePlaceholder: "<img>",
+				FilePlaceholder:  "<{name} {size}B>",
+				LocationUrl:      "-",
+				Separator:        "|",
+				Custom: func(c *types.MsgCustomContent, format Format) (string, bool) {
+					return "order", c.Data == `{"type":"order"}`
+				},
+			}).Text,
+			want: "see *this*|<img>|[Voice 3s]|<a.txt 9B>|[Location: Office]|order|[Unsupported Message]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.render(body); got != tt.want {
+				t.Errorf("render() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderer_Relay(t *testing.T) {
+	body := NewBuilder().Relay(types.MsgRelayContent{
+		Title:        "Group chat",
+		AbstractList: []string{"user1: hi", "user2: hello"},
+		MsgList:      []*types.RelayMessage{{}, {}},
+	}).Body()
+
+	if got, want := RenderText(body), "[Chat History: Group chat]\n  user1: hi\n  user2: hello"; got != want {
+		t.Errorf("RenderText() = %q, want %q", got, want)
+	}
+	if got, want := RenderMarkdown(body), "\\[Chat History: Group chat\\]\n\n> user1: hi\n\n> user2: hello"; got != want {
+		t.Errorf("RenderMarkdown() = %q, want %q", got, want)
+	}
+}
+
+func TestRenderer_HostileUrl(t *testing.T) {
+	tests := []struct {
+		name string
+		body []*types.MsgBody
+		want string
+	}{
+		{
+			name: "javascript scheme",
+			body: NewBuilder().File("f1", "javascript:alert(1)", "a.txt", 9).Body(),
+			want: `\[File: a.txt\]`,
+		},
+		{
+			name: "mixed case scheme with leading space",
+			body: NewBuilder().Sound("s1", " JaVaScRiPt:alert(1)", 10, 3).Body(),
+			want: `\[Voice 3s\]`,
+		},
+		{
+			name: "data image",
+			body: NewBuilder().Image("img1", ImageFormatPNG, NewImageInfo(ImageTypeLarge, "data:text/html;base64,PHNjcmlwdD4=", 1, 1, 1)).Body(),
+			want: `\[Image\]`,
+		},
+		{
+			name: "relative url",
+			body: NewBuilder().File("f1", "//evil.com/a", "a.txt", 9).Body(),
+			want: `\[File: a.txt\]`,
+		},
+		{
+			name: "link breakout",
+			body: NewBuilder().File("f1", `https://a.com/x) <img src=x onerror=alert(1)> (`, "a.txt", 9).Body(),
+			want: `[\[File: a.txt\]](https://a.com/x%29%20%3Cimg%20src=x%20onerror=alert%281%29%3E%20%28)`,
+		},
+		{
+			name: "control characters",
+			body: NewBuilder().File("f1", "https://a.com/\n[x](javascript:alert(1))", "a.txt", 9).Body(),
+			want: `\[File: a.txt\]`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderMarkdown(tt.body); got != tt.want {
+				t.Errorf("RenderMarkdown() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	r := NewRenderer(RenderOptions{LocationUrl: "javascript:alert({lat})"})
+	body := []*types.MsgBody{{MsgType: "TIMLocationElem", MsgContent: &types.MsgLocationContent{Desc: "<b>Office</b>", Latitude: 1, Longitude: 2}}}
+	if got, want := r.Markdown(body), `\[Location: \<b\>Office\</b\>\]`; got != want {
+		t.Errorf("Markdown() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,328 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 基于 Aho–Corasick 自动机的敏感词匹配器
+ */
+
+package moderation
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"unicode"
+)
+
+const DefaultCategory = "default" // 默认敏感词分类
+
+type (
+	// Match 敏感词命中结果
+	Match struct {
+		Word     string // 命中的敏感词（原文）
+		Category string // 敏感词分类
+		Start    int    // 起始位置（按字符计算，包含）
+		End      int    // 结束位置（按字符计算，不包含）
+	}
+
+	// Matcher 敏感词匹配器，匹配时忽略大小写，可并发使用
+	Matcher struct {
+		mu        sync.RWMutex
+		words     map[string]string // 敏感词 => 分类
+		whitelist map[string]bool   // 白名单
+		automaton *automaton        // 已构建的自动机，词表变更后置空
+	}
+
+	// pattern 模式串
+	pattern struct {
+		word      string
+		category  string
+		length    int
+		whitelist bool
+	}
+
+	// node 自动机节点
+	node struct {
+		children map[rune]int
+		fail     int
+		outputs  []int
+	}
+
+	// automaton Aho–Corasick 自动机
+	automaton struct {
+		nodes    []*node
+		patterns []*pattern
+	}
+)
+
+// NewMatcher 新建敏感词匹配器
+func NewMatcher() *Matcher {
+	return &Matcher{
+		words:     make(map[string]string),
+		whitelist: make(map[string]bool),
+	}
+}
+
+// AddWords 添加敏感词，category 为空时使用默认分类，同一敏感词重复添加时以最后一次的分类为准
+func (m *Matcher) AddWords(category string, words ...string) {
+	if category == "" {
+		category = DefaultCategory
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, word := range words {
+		if word = normalizeWord(word); word != "" {
+			m.words[word] = category
+		}
+	}
+	m.automaton = nil
+}
+
+// AddWhitelist 添加白名单，被白名单词语完全覆盖的敏感词不视为命中（如白名单 "class" 可避免命中 "ass"）
+func (m *Matcher) AddWhitelist(words ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, word := range words {
+		if word = normalizeWord(word); word != "" {
+			m.whitelist[word] = true
+		}
+	}
+	m.automaton = nil
+}
+
+// Load 从词表读取敏感词，每行一个词，忽略空行及以 # 开头的注释行
+func (m *Matcher) Load(category string, r io.Reader) error {
+	words, err := readWords(r)
+	if err != nil {
+		return err
+	}
+
+	m.AddWords(category, words...)
+
+	return nil
+}
+
+// LoadFile 从词表文件读取敏感词
+func (m *Matcher) LoadFile(category, path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return m.Load(category, file)
+}
+
+// LoadWhitelist 从词表读取白名单
+func (m *Matcher) LoadWhitelist(r io.Reader) error {
+	words, err := readWords(r)
+	if err != nil {
+		return err
+	}
+
+	m.AddWhitelist(words...)
+
+	return nil
+}
+
+// Find 查找文本中的全部敏感词，按起始位置排序
+func (m *Matcher) Find(text string) []Match {
+	if text == "" {
+		return nil
+	}
+
+	return m.build().find([]rune(text))
+}
+
+// Contains 文本中是否包含敏感词
+func (m *Matcher) Contains(text string) bool {
+	return len(m.Find(text)) > 0
+}
+
+// Mask 将文本中的敏感词替换为掩码字符（每个字符替换为一个掩码字符）
+func (m *Matcher) Mask(text string, mask rune) (string, []Match) {
+	if text == "" {
+		return text, nil
+	}
+
+	runes := []rune(text)
+	matches := m.build().find(runes)
+	if len(matches) == 0 {
+		return text, nil
+	}
+
+	for _, match := range matches {
+		for i := match.Start; i < match.End; i++ {
+			runes[i] = mask
+		}
+	}
+
+	return string(runes), matches
+}
+
+// build 获取自动机，词表变更后重新构建
+func (m *Matcher) build() *automaton {
+	m.mu.RLock()
+	a := m.automaton
+	m.mu.RUnlock()
+
+	if a != nil {
+		return a
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.automaton == nil {
+		m.automaton = newAutomaton(m.words, m.whitelist)
+	}
+
+	return m.automaton
+}
+
+// newAutomaton 构建 Aho–Corasick 自动机
+func newAutomaton(words map[string]string, whitelist map[string]bool) *automaton {
+	a := &automaton{nodes: []*node{newNode()}}
+
+	for word, category := range words {
+		a.insert(&pattern{word: word, category: category})
+	}
+	for word := range whitelist {
+		a.insert(&pattern{word: word, whitelist: true})
+	}
+
+	// 按广度优先设置失败指针，并合并失败指针所指节点的输出
+	queue := make([]int, 0, len(a.nodes))
+	for _, child := range a.nodes[0].children {
+		queue = append(queue, child)
+	}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for r, child := range a.nodes[current].children {
+			fail := a.nodes[current].fail
+			for fail > 0 && a.nodes[fail].children[r] == 0 {
+				fail = a.nodes[fail].fail
+			}
+			if next, ok := a.nodes[fail].children[r]; ok && next != child {
+				fail = next
+			}
+
+			a.nodes[child].fail = fail
+			a.nodes[child].outputs = append(a.nodes[child].outputs, a.nodes[fail].outputs...)
+			queue = append(queue, child)
+		}
+	}
+
+	return a
+}
+
+// insert 插入模式串
+func (a *automaton) insert(p *pattern) {
+	current := 0
+	for _, r := range p.word {
+		next, ok := a.nodes[current].children[r]
+		if !ok {
+			next = len(a.nodes)
+			a.nodes = append(a.nodes, newNode())
+			a.nodes[current].children[r] = next
+		}
+		current = next
+		p.length++
+	}
+
+	a.patterns = append(a.patterns, p)
+	a.nodes[current].outputs = append(a.nodes[current].outputs, len(a.patterns)-1)
+}
+
+// find 查找敏感词并排除被白名单覆盖的命中
+func (a *automaton) find(text []rune) []Match {
+	var (
+		matches []Match
+		allowed [][2]int
+		current int
+	)
+
+	for i, r := range text {
+		r = unicode.ToLower(r)
+		for current > 0 && a.nodes[current].children[r] == 0 {
+			current = a.nodes[current].fail
+		}
+		current = a.nodes[current].children[r]
+
+		for _, idx := range a.nodes[current].outputs {
+			p := a.patterns[idx]
+			start, end := i+1-p.length, i+1
+			if p.whitelist {
+				allowed = append(allowed, [2]int{start, end})
+				continue
+			}
+			matches = append(matches, Match{Word: string(text[start:end]), Category: p.category, Start: start, End: end})
+		}
+	}
+
+	if len(allowed) == 0 || len(matches) == 0 {
+		return sortMatches(matches)
+	}
+
+	ret := matches[:0]
+	for _, match := range matches {
+		covered := false
+		for _, w := range allowed {
+			if w[0] <= match.Start && match.End <= w[1] {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			ret = append(ret, match)
+		}
+	}
+
+	return sortMatches(ret)
+}
+
+// sortMatches 按起始位置排序，起始位置相同时较长的在前
+func sortMatches(matches []Match) []Match {
+	sort.Slice(matches, func(i, j int) bool {
+		if matches[i].Start != matches[j].Start {
+			return matches[i].Start < matches[j].Start
+		}
+		return matches[i].End > matches[j].End
+	})
+
+	return matches
+}
+
+// newNode 新建自动机节点
+func newNode() *node {
+	return &node{children: make(map[rune]int)}
+}
+
+// normalizeWord 规范化词语
+func normalizeWord(word string) string {
+	return strings.Map(unicode.ToLower, strings.TrimSpace(word))
+}
+
+// readWords 读取词表
+func readWords(r io.Reader) ([]string, error) {
+	var words []string
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words = append(words, line)
+	}
+
+	return words, scanner.Err()
+}
@@ -0,0 +1,81 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 敏感词匹配器单元测试
+ */
+
+package moderation
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestMatcher_Find(t *testing.T) {
+	m := NewMatcher()
+	m.AddWords("abuse", "he", "she", "hers", "坏蛋")
+	m.AddWords("spam", "Buy Now")
+	m.AddWhitelist("shell")
+
+	tests := []struct {
+		name string
+		text string
+		want []Match
+	}{
+		{
+			name: "overlapping words",
+			text: "ushers",
+			want: []Match{
+				{Word: "she", Category: "abuse", Start: 1, End: 4},
+				{Word: "hers", Category: "abuse", Start: 2, End: 6},
+				{Word: "he", Category: "abuse", Start: 2, End: 4},
+			},
+		},
+		{
+			name: "case insensitive and unicode",
+			text: "你这个坏蛋 BUY NOW",
+			want: []Match{
+				{Word: "坏蛋", Category: "abuse", Start: 3, End: 5},
+				{Word: "BUY NOW", Category: "spam", Start: 6, End: 13},
+			},
+		},
+		{
+			name: "whitelist",
+			text: "shell",
+		},
+		{
+			name: "prefix of longer text",
+			text: "hello",
+			want: []Match{{Word: "he", Category: "abuse", Start: 0, End: 2}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := m.Find(tt.text); !reflect.DeepEqual(got, tt.want) && !(len(got) == 0 && len(tt.want) == 0) {
+				t.Errorf("Find() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatcher_LoadAndMask(t *testing.T) {
+	m := NewMatcher()
+	if err := m.Load("", strings.NewReader("# comment\n\nbad\n 坏蛋 \n")); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+
+	got, matches := m.Mask("bad 坏蛋!", '*')
+	if got != "*** **!" {
+		t.Errorf("Mask() = %q, want %q", got, "*** **!")
+	}
+	if len(matches) != 2 || matches[0].Category != DefaultCategory {
+		t.Errorf("matches = %+v", matches)
+	}
+
+	m.AddWords("", "good")
+	if !m.Contains("good") {
+		t.Error("Contains() = false after AddWords, want true")
+	}
+}
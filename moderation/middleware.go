@@ -0,0 +1,211 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 敏感词过滤回调中间件
+ */
+
+package moderation
+
+import (
+	"context"
+
+	"github.com/d60-Lab/tencent-im/callback"
+	"github.com/d60-Lab/tencent-im/internal/enum"
+	"github.com/d60-Lab/tencent-im/internal/types"
+)
+
+const (
+	ActionFlag  Action = iota // 标记：消息正常下发，处理器可通过 ResultFromContext 获取命中结果
+	ActionMask                // 掩码：将敏感词替换为掩码字符后下发
+	ActionBlock               // 拦截：拒绝下发消息
+)
+
+const (
+	BlockCodeRefuse  = 1 // 拒绝发送，发送方将收到错误
+	BlockCodeDiscard = 2 // 静默丢弃，发送方认为发送成功
+
+	defaultMaskRune     = '*'
+	defaultBlockMessage = "message contains sensitive words"
+)
+
+type (
+	// Action 命中敏感词后的处理方式
+	Action int
+
+	// Options 敏感词过滤配置
+	Options struct {
+		Matcher      *Matcher                                                        // （必填）敏感词匹配器
+		Action       Action                                                          // （选填）命中后的默认处理方式，默认为标记
+		Categories   map[string]Action                                               // （选填）按分类指定处理方式，命中多个分类时取最严格的处理方式
+		MaskRune     rune                                                            // （选填）掩码字符，默认为 *
+		BlockCode    int                                                             // （选填）拦截时的应答错误码，可取 1、2 或 120001-130000 的自定义错误码，默认为1
+		BlockMessage string                                                          // （选填）拦截时的应答错误信息
+		OnMatch      func(ctx context.Context, event callback.Event, result *Result) // （选填）命中敏感词时的回调，可用于记录审核日志
+	}
+
+	// Result 敏感词过滤结果
+	Result struct {
+		Action  Action  // 最终的处理方式
+		Matches []Match // 命中的敏感词
+	}
+
+	resultKey struct{}
+
+	// maskAck 掩码处理时的应答器，将处理器的默认成功应答替换为携带修改后消息体的应答
+	maskAck struct {
+		ack   callback.Ack
+		event callback.Event
+		body  []*types.MsgBody
+	}
+)
+
+// Middleware 新建敏感词过滤中间件，仅对发单聊消息之前回调及群内发言之前回调生效
+// 拦截时直接应答，不再调用后续处理器；掩码时处理器收到的消息体已替换为掩码后的内容
+func Middleware(opt Options) callback.Middleware {
+	if opt.MaskRune == 0 {
+		opt.MaskRune = defaultMaskRune
+	}
+	if opt.BlockCode == 0 {
+		opt.BlockCode = BlockCodeRefuse
+	}
+	if opt.BlockMessage == "" {
+		opt.BlockMessage = defaultBlockMessage
+	}
+
+	return func(next callback.HandlerFunc) callback.HandlerFunc {
+		return func(ctx context.Context, event callback.Event, ack callback.Ack, data interface{}) {
+			body := messageBody(data)
+			if opt.Matcher == nil || body == nil {
+				next(ctx, event, ack, data)
+				return
+			}
+
+			masked, result := opt.moderate(*body)
+			if result == nil {
+				next(ctx, event, ack, data)
+				return
+			}
+
+			ctx = context.WithValue(ctx, resultKey{}, result)
+			if opt.OnMatch != nil {
+				opt.OnMatch(ctx, event, result)
+			}
+
+			switch result.Action {
+			case ActionBlock:
+				_ = ack.AckSuccess(opt.BlockCode, opt.BlockMessage)
+			case ActionMask:
+				*body = masked
+				next(ctx, event, &maskAck{ack: ack, event: event, body: masked}, data)
+			default:
+				next(ctx, event, ack, data)
+			}
+		}
+	}
+}
+
+// ResultFromContext 从上下文中获取敏感词过滤结果
+func ResultFromContext(ctx context.Context) (*Result, bool) {
+	result, ok := ctx.Value(resultKey{}).(*Result)
+	return result, ok
+}
+
+// moderate 过滤消息体中的文本元素，未命中时返回 nil
+func (o *Options) moderate(body []*types.MsgBody) ([]*types.MsgBody, *Result) {
+	var (
+		result *Result
+		masked = make([]*types.MsgBody, len(body))
+	)
+
+	for i, item := range body {
+		masked[i] = item
+
+		text, ok := textOf(item)
+		if !ok {
+			continue
+		}
+
+		s, matches := o.Matcher.Mask(text, o.MaskRune)
+		if len(matches) == 0 {
+			continue
+		}
+
+		if result == nil {
+			result = &Result{Action: o.Action}
+		}
+		result.Matches = append(result.Matches, matches...)
+		for _, match := range matches {
+			if action, ok := o.Categories[match.Category]; ok && action > result.Action {
+				result.Action = action
+			}
+		}
+
+		masked[i] = &types.MsgBody{MsgType: enum.MsgText, MsgContent: &types.MsgTextContent{Text: s}}
+	}
+
+	return masked, result
+}
+
+// messageBody 获取发消息之前回调中的消息体
+func messageBody(data interface{}) *[]*types.MsgBody {
+	switch d := data.(type) {
+	case *callback.BeforePrivateMessageSend:
+		return &d.MsgBody
+	case *callback.BeforeGroupMessageSend:
+		return &d.MsgBody
+	}
+
+	return nil
+}
+
+// textOf 获取文本消息元素的内容
+func textOf(item *types.MsgBody) (string, bool) {
+	if item == nil {
+		return "", false
+	}
+
+	switch c := item.MsgContent.(type) {
+	case *types.MsgTextContent:
+		return c.Text, true
+	case types.MsgTextContent:
+		return c.Text, true
+	}
+
+	return "", false
+}
+
+// Ack 应答
+func (a *maskAck) Ack(resp interface{}) error {
+	return a.ack.Ack(resp)
+}
+
+// AckFailure 失败应答
+func (a *maskAck) AckFailure(message ...string) error {
+	return a.ack.AckFailure(message...)
+}
+
+// AckSuccess 成功应答，错误码为0时携带掩码后的消息体
+func (a *maskAck) AckSuccess(code int, message ...string) error {
+	if code != 0 {
+		return a.ack.AckSuccess(code, message...)
+	}
+
+	resp := callback.BaseResp{ActionStatus: "OK"}
+	if len(message) > 0 {
+		resp.ErrorInfo = message[0]
+	}
+
+	switch a.event {
+	case callback.EventBeforePrivateMessageSend:
+		return a.ack.Ack(&callback.BeforePrivateMessageSendResp{BaseResp: resp, MsgBody: a.body})
+	case callback.EventBeforeGroupMessageSend:
+		return a.ack.Ack(&callback.BeforeGroupMessageSendResp{BaseResp: resp, MsgBody: a.body})
+	}
+
+	return a.ack.Ack(resp)
+}
+
+// Unwrap 获取被包装的应答器
+func (a *maskAck) Unwrap() callback.Ack {
+	return a.ack
+}
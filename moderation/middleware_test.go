@@ -0,0 +1,133 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 敏感词过滤回调中间件单元测试
+ */
+
+package moderation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/d60-Lab/tencent-im/callback"
+	"github.com/d60-Lab/tencent-im/callback/callbacktest"
+	"github.com/d60-Lab/tencent-im/internal/types"
+)
+
+const testAppId = 1400000000
+
+func newTestSimulator(opt Options, handler callback.ContextEventHandlerFunc) *callbacktest.Simulator {
+	c := callback.NewCallback(testAppId)
+	c.Use(Middleware(opt))
+	if handler != nil {
+		c.RegisterContext(callback.EventBeforeGroupMessageSend, handler)
+		c.RegisterContext(callback.EventBeforePrivateMessageSend, handler)
+	}
+
+	return callbacktest.NewSimulator(c, callbacktest.Options{AppId: testAppId})
+}
+
+func textBody(text string) map[string]interface{} {
+	return map[string]interface{}{
+		"MsgBody": []map[string]interface{}{{"MsgType": "TIMTextElem", "MsgContent": map[string]string{"Text": text}}},
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	m := NewMatcher()
+	m.AddWords("abuse", "badword")
+	m.AddWords("fraud", "scam")
+
+	t.Run("block", func(t *testing.T) {
+		called := false
+		s := newTestSimulator(Options{Matcher: m, Categories: map[string]Action{"fraud": ActionBlock}}, func(ctx context.Context, ack callback.Ack, data interface{}) {
+			called = true
+		})
+
+		ret, err := s.Send(callback.EventBeforeGroupMessageSend, textBody("this is a scam"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ret.Resp.ActionStatus != "OK" || ret.Resp.ErrorCode != BlockCodeRefuse {
+			t.Errorf("ack = %+v, want refuse", ret.Resp)
+		}
+		if called {
+			t.Error("handler was called for a blocked message")
+		}
+	})
+
+	t.Run("mask", func(t *testing.T) {
+		var seen string
+		s := newTestSimulator(Options{Matcher: m, Action: ActionMask}, func(ctx context.Context, ack callback.Ack, data interface{}) {
+			seen = data.(*callback.BeforePrivateMessageSend).MsgBody[0].MsgContent.(*types.MsgTextContent).Text
+			_ = ack.AckSuccess(0)
+		})
+
+		ret, err := s.Send(callback.EventBeforePrivateMessageSend, textBody("you badword"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resp := &callback.BeforePrivateMessageSendResp{}
+		if err = ret.Decode(resp); err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.MsgBody) != 1 || resp.MsgBody[0].MsgContent.(*types.MsgTextContent).Text != "you *******" {
+			t.Errorf("MsgBody = %+v, want masked text", resp.MsgBody)
+		}
+		if seen != "you *******" {
+			t.Errorf("handler saw %q, want masked text", seen)
+		}
+	})
+
+	t.Run("mask without handler", func(t *testing.T) {
+		ret, err := newTestSimulator(Options{Matcher: m, Action: ActionMask}, nil).Send(callback.EventBeforeGroupMessageSend, textBody("badword"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		resp := &callback.BeforeGroupMessageSendResp{}
+		if err = ret.Decode(resp); err != nil {
+			t.Fatal(err)
+		}
+		if len(resp.MsgBody) != 1 || resp.MsgBody[0].MsgContent.(*types.MsgTextContent).Text != "*******" {
+			t.Errorf("MsgBody = %+v, want masked text", resp.MsgBody)
+		}
+	})
+
+	t.Run("flag", func(t *testing.T) {
+		var result *Result
+		s := newTestSimulator(Options{Matcher: m}, func(ctx context.Context, ack callback.Ack, data interface{}) {
+			result, _ = ResultFromContext(ctx)
+			_ = ack.AckSuccess(0)
+		})
+
+		ret, err := s.Send(callback.EventBeforeGroupMessageSend, textBody("badword"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ret.OK() || string(ret.Body) != `{"ErrorCode":0,"ErrorInfo":"","ActionStatus":"OK"}` {
+			t.Errorf("ack = %s, want plain success", ret.Body)
+		}
+		if result == nil || result.Action != ActionFlag || len(result.Matches) != 1 {
+			t.Errorf("result = %+v, want flagged match", result)
+		}
+	})
+
+	t.Run("clean message", func(t *testing.T) {
+		var ok bool
+		s := newTestSimulator(Options{Matcher: m, Action: ActionBlock}, func(ctx context.Context, ack callback.Ack, data interface{}) {
+			_, ok = ResultFromContext(ctx)
+			_ = ack.AckSuccess(0)
+		})
+
+		ret, err := s.Send(callback.EventBeforeGroupMessageSend, textBody("hello"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !ret.OK() || ok {
+			t.Errorf("ack = %+v, result present = %v", ret.Resp, ok)
+		}
+	})
+}
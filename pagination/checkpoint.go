@@ -0,0 +1,216 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 分页游标检查点及断点续拉导出
+ */
+
+package pagination
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+const defaultCheckpointEvery = 100
+
+// ErrStop 由 Export 的处理函数返回，表示已导出所需的全部数据，Export 将保存已完成的游标并返回 nil
+var ErrStop = errors.New("pagination: stop export")
+
+type (
+	// Checkpoint 游标检查点存储
+	Checkpoint[C comparable] interface {
+		// Load 加载已保存的游标，未保存过游标时 ok 为 false
+		Load() (cursor Cursor[C], ok bool, err error)
+		// Save 保存游标
+		Save(cursor Cursor[C]) error
+	}
+
+	// FileCheckpoint 基于本地文件的游标检查点
+	FileCheckpoint[C comparable] struct {
+		path string
+	}
+
+	// MemoryCheckpoint 基于内存的游标检查点，仅在当前进程内有效
+	MemoryCheckpoint[C comparable] struct {
+		mu     sync.Mutex
+		cursor Cursor[C]
+		saved  bool
+	}
+
+	// ExportOptions 导出配置
+	ExportOptions struct {
+		Every    int           // （选填）每成功处理多少条数据保存一次游标，默认为 100
+		Interval time.Duration // （选填）距上次保存超过该时长时保存游标，默认不按时长保存
+		Flush    func() error  // （选填）保存游标前调用，用于将已处理的数据落盘，保证游标不会超前于已写出的数据
+	}
+)
+
+// NewFileCheckpoint 新建基于本地文件的游标检查点
+// 游标以 JSON 格式保存在 path 文件中，写入时先写临时文件再重命名，避免进程中断产生不完整的文件
+func NewFileCheckpoint[C comparable](path string) *FileCheckpoint[C] {
+	return &FileCheckpoint[C]{path: path}
+}
+
+// Load 加载已保存的游标
+func (f *FileCheckpoint[C]) Load() (cursor Cursor[C], ok bool, err error) {
+	b, err := os.ReadFile(f.path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			err = nil
+		}
+		return
+	}
+
+	if err = json.Unmarshal(b, &cursor); err != nil {
+		return
+	}
+
+	ok = true
+
+	return
+}
+
+// Save 保存游标
+func (f *FileCheckpoint[C]) Save(cursor Cursor[C]) error {
+	b, err := json.Marshal(cursor)
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(f.path)
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+
+	if _, err = tmp.Write(b); err == nil {
+		err = tmp.Sync()
+	}
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	if err = os.Rename(tmp.Name(), f.path); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+
+	return nil
+}
+
+// Remove 删除已保存的游标
+func (f *FileCheckpoint[C]) Remove() error {
+	if err := os.Remove(f.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	return nil
+}
+
+// NewMemoryCheckpoint 新建基于内存的游标检查点
+func NewMemoryCheckpoint[C comparable]() *MemoryCheckpoint[C] {
+	return &MemoryCheckpoint[C]{}
+}
+
+// Load 加载已保存的游标
+func (m *MemoryCheckpoint[C]) Load() (cursor Cursor[C], ok bool, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.cursor, m.saved, nil
+}
+
+// Save 保存游标
+func (m *MemoryCheckpoint[C]) Save(cursor Cursor[C]) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.cursor, m.saved = cursor, true
+
+	return nil
+}
+
+// Export 断点续拉导出全部数据
+// 先从检查点加载游标并定位迭代器，随后逐条调用 fn 处理数据，并按配置定期保存游标；
+// 出错或上下文取消时保存最后一条处理成功的数据之后的游标并返回错误，再次调用即可从该位置继续导出；
+// fn 返回 ErrStop 时视为导出完成，保存已完成的游标，再次调用不会继续拉取
+func Export[T any, C comparable](ctx context.Context, pager *Pager[T, C], checkpoint Checkpoint[C], fn func(item T) error, opts ...ExportOptions) (err error) {
+	var opt ExportOptions
+	if len(opts) > 0 {
+		opt = opts[0]
+	}
+	if opt.Every <= 0 {
+		opt.Every = defaultCheckpointEvery
+	}
+
+	cursor, ok, err := checkpoint.Load()
+	if err != nil {
+		return
+	}
+	if ok {
+		pager.Seek(cursor)
+	}
+
+	var (
+		pending   int
+		lastSaved = time.Now()
+		processed = pager.Cursor()
+	)
+
+	save := func() error {
+		if opt.Flush != nil {
+			if err := opt.Flush(); err != nil {
+				return err
+			}
+		}
+
+		pending, lastSaved = 0, time.Now()
+
+		return checkpoint.Save(processed)
+	}
+
+	for item, e := range pager.All(ctx) {
+		if e != nil {
+			err = e
+			break
+		}
+
+		if err = fn(item); err != nil {
+			break
+		}
+
+		processed = pager.Cursor()
+		pending++
+
+		if pending >= opt.Every || (opt.Interval > 0 && time.Since(lastSaved) >= opt.Interval) {
+			if err = save(); err != nil {
+				return
+			}
+		}
+	}
+
+	if errors.Is(err, ErrStop) {
+		processed.Done, err = true, nil
+	} else if err == nil {
+		processed = pager.Cursor()
+	}
+
+	if saveErr := save(); err == nil {
+		err = saveErr
+	}
+
+	return
+}
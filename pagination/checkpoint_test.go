@@ -0,0 +1,160 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 游标检查点及断点续拉导出单元测试
+ */
+
+package pagination
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestFileCheckpoint(t *testing.T) {
+	cp := NewFileCheckpoint[int](filepath.Join(t.TempDir(), "sub", "cursor.json"))
+
+	if _, ok, err := cp.Load(); err != nil || ok {
+		t.Fatalf("expected empty checkpoint, got %v, %v", ok, err)
+	}
+
+	if err := cp.Save(Cursor[int]{Page: 4, Offset: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if cursor, ok, err := cp.Load(); err != nil || !ok || cursor != (Cursor[int]{Page: 4, Offset: 1}) {
+		t.Fatalf("unexpected cursor: %+v, %v, %v", cursor, ok, err)
+	}
+
+	if err := cp.Remove(); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, _ := cp.Load(); ok {
+		t.Fatal("checkpoint should be removed")
+	}
+}
+
+func TestExport(t *testing.T) {
+	var (
+		calls   int
+		flushed int
+		written []int
+		failAt  = 4
+		boom    = errors.New("boom")
+		cp      = NewFileCheckpoint[int](filepath.Join(t.TempDir(), "cursor.json"))
+		opt     = ExportOptions{Every: 2, Flush: func() error { flushed++; return nil }}
+	)
+
+	fn := func(item int) error {
+		if item == failAt {
+			return boom
+		}
+		written = append(written, item)
+		return nil
+	}
+
+	err := Export(context.Background(), New(pages([]int{1, 2, 3, 4, 5}, nil, &calls)), cp, fn, opt)
+	if !errors.Is(err, boom) {
+		t.Fatalf("expected boom, got %v", err)
+	}
+	if cursor, _, _ := cp.Load(); cursor != (Cursor[int]{Page: 2, Offset: 1}) {
+		t.Fatalf("checkpoint should stop before the failed item: %+v", cursor)
+	}
+
+	// 以新的迭代器续导，失败的数据将被重新处理
+	failAt = 0
+	if err = Export(context.Background(), New(pages([]int{1, 2, 3, 4, 5}, nil, &calls)), cp, fn, opt); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(written, []int{1, 2, 3, 4, 5}) || flushed == 0 {
+		t.Fatalf("unexpected export: %v, flushed %d", written, flushed)
+	}
+
+	// 已导出完成时不再拉取
+	calls = 0
+	if err = Export(context.Background(), New(pages([]int{1, 2, 3, 4, 5}, nil, &calls)), cp, fn, opt); err != nil || calls != 0 {
+		t.Fatalf("unexpected re-export: %v, calls %d", err, calls)
+	}
+}
+
+func TestExport_Stop(t *testing.T) {
+	var (
+		calls   int
+		written []int
+		cp      = NewMemoryCheckpoint[int]()
+	)
+
+	fn := func(item int) error {
+		if item == 3 {
+			return ErrStop
+		}
+		written = append(written, item)
+		return nil
+	}
+
+	if err := Export(context.Background(), New(pages([]int{1, 2, 3, 4, 5}, nil, &calls)), cp, fn); err != nil {
+		t.Fatal(err)
+	}
+	if cursor, _, _ := cp.Load(); !cursor.Done || !reflect.DeepEqual(written, []int{1, 2}) {
+		t.Fatalf("unexpected export: %v, cursor %+v", written, cursor)
+	}
+
+	// 已停止的导出视为完成，不再拉取
+	calls = 0
+	if err := Export(context.Background(), New(pages([]int{1, 2, 3, 4, 5}, nil, &calls)), cp, fn); err != nil || calls != 0 {
+		t.Fatalf("unexpected re-export: %v, calls %d", err, calls)
+	}
+}
+
+func TestExport_PinnedFirstPage(t *testing.T) {
+	var (
+		latest  = 5
+		written []int
+		boom    = errors.New("boom")
+		cp      = NewMemoryCheckpoint[int]()
+	)
+
+	// 游标为 0 时从最新数据开始拉取，首页固定为实际的起始位置
+	fetch := func(ctx context.Context, cursor int) (*Page[int, int], error) {
+		from := cursor
+		if from == 0 {
+			from = latest
+		}
+
+		page := &Page[int, int]{Next: from - 2, HasMore: from-2 > 0}
+		for i := from; i > 0 && i > from-2; i-- {
+			page.Items = append(page.Items, i)
+		}
+		if cursor == 0 {
+			page.Pin = from
+		}
+
+		return page, nil
+	}
+
+	fn := func(item int) error {
+		if item == 4 && latest == 5 {
+			return boom
+		}
+		written = append(written, item)
+		return nil
+	}
+
+	if err := Export(context.Background(), New(fetch), cp, fn); !errors.Is(err, boom) {
+		t.Fatalf("expected boom, got %v", err)
+	}
+	if cursor, _, _ := cp.Load(); cursor != (Cursor[int]{Page: 5, Offset: 1}) {
+		t.Fatalf("checkpoint should be pinned to the first page: %+v", cursor)
+	}
+
+	// 续导前有新数据到达，续导仍从中断的位置继续
+	latest = 8
+	if err := Export(context.Background(), New(fetch), cp, fn); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(written, []int{5, 4, 3, 2, 1}) {
+		t.Fatalf("unexpected export: %v", written)
+	}
+}
@@ -0,0 +1,162 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 基于 iter.Seq2 的统一分页迭代器
+ */
+
+package pagination
+
+import (
+	"context"
+	"errors"
+	"iter"
+)
+
+// ErrStalled 分页游标未向前推进，继续拉取将陷入死循环
+var ErrStalled = errors.New("pagination: cursor did not advance")
+
+type (
+	// Page 单页拉取结果
+	Page[T any, C comparable] struct {
+		Items   []T  // 本页数据
+		Next    C    // 下一页游标
+		HasMore bool // 是否还有更多数据
+		Pin     C    // （选填）本页对应的固定游标，请求游标为“最新”等相对位置时设置，迭代位置将改用该游标以免续拉时定位到其他数据
+	}
+
+	// Fetcher 按游标拉取单页数据
+	Fetcher[T any, C comparable] func(ctx context.Context, cursor C) (*Page[T, C], error)
+
+	// Cursor 可序列化的迭代位置，由页游标及页内偏移组成
+	Cursor[C comparable] struct {
+		Page   C    `json:"page"`   // 当前页游标
+		Offset int  `json:"offset"` // 当前页内已迭代的条目数
+		Done   bool `json:"done"`   // 是否已迭代完全部数据
+	}
+
+	// Pager 分页迭代器
+	Pager[T any, C comparable] struct {
+		fetch  Fetcher[T, C]
+		cursor Cursor[C]
+		err    error
+	}
+)
+
+// New 新建分页迭代器，可传入此前保存的游标以续拉
+func New[T any, C comparable](fetch Fetcher[T, C], start ...Cursor[C]) *Pager[T, C] {
+	p := &Pager[T, C]{fetch: fetch}
+	if len(start) > 0 {
+		p.cursor = start[0]
+	}
+
+	return p
+}
+
+// All 逐条迭代全部数据
+// 拉取失败或上下文取消时产出一次错误后结束迭代，游标停留在最后一条已产出的数据之后，再次调用 All 将从该位置续拉
+func (p *Pager[T, C]) All(ctx context.Context) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+
+		p.err = nil
+
+		for !p.cursor.Done {
+			if err := ctx.Err(); err != nil {
+				p.err = err
+				yield(zero, err)
+				return
+			}
+
+			page, err := p.fetch(ctx, p.cursor.Page)
+			if err != nil {
+				p.err = err
+				yield(zero, err)
+				return
+			}
+
+			var unpinned C
+			if page.Pin != unpinned {
+				p.cursor.Page = page.Pin
+			}
+
+			for p.cursor.Offset < len(page.Items) {
+				if err = ctx.Err(); err != nil {
+					p.err = err
+					yield(zero, err)
+					return
+				}
+
+				item := page.Items[p.cursor.Offset]
+				p.cursor.Offset++
+
+				if !yield(item, nil) {
+					p.advance(page)
+					return
+				}
+			}
+
+			if err = p.next(page); err != nil {
+				p.err = err
+				yield(zero, err)
+				return
+			}
+		}
+	}
+}
+
+// Seek 定位至指定的迭代位置，下一次调用 All 时将从该位置续拉
+func (p *Pager[T, C]) Seek(cursor Cursor[C]) {
+	p.cursor = cursor
+	p.err = nil
+}
+
+// Cursor 获取当前迭代位置
+func (p *Pager[T, C]) Cursor() Cursor[C] {
+	return p.cursor
+}
+
+// Done 是否已迭代完全部数据
+func (p *Pager[T, C]) Done() bool {
+	return p.cursor.Done
+}
+
+// Err 获取最近一次迭代的错误
+func (p *Pager[T, C]) Err() error {
+	return p.err
+}
+
+// advance 本页数据已全部产出时，将游标移至下一页
+func (p *Pager[T, C]) advance(page *Page[T, C]) {
+	if p.cursor.Offset >= len(page.Items) {
+		p.err = p.next(page)
+	}
+}
+
+// next 将游标移至下一页
+func (p *Pager[T, C]) next(page *Page[T, C]) error {
+	if !page.HasMore {
+		p.cursor = Cursor[C]{Page: p.cursor.Page, Offset: p.cursor.Offset, Done: true}
+		return nil
+	}
+
+	if page.Next == p.cursor.Page {
+		return ErrStalled
+	}
+
+	p.cursor = Cursor[C]{Page: page.Next}
+
+	return nil
+}
+
+// Collect 迭代并收集全部数据
+func Collect[T any](seq iter.Seq2[T, error]) (items []T, err error) {
+	for item, e := range seq {
+		if e != nil {
+			err = e
+			return
+		}
+		items = append(items, item)
+	}
+
+	return
+}
@@ -0,0 +1,129 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 分页迭代器单元测试
+ */
+
+package pagination
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+// pages 以偏移量为游标、每页两条数据的测试拉取函数
+func pages(items []int, fails map[int]error, calls *int) Fetcher[int, int] {
+	return func(ctx context.Context, cursor int) (*Page[int, int], error) {
+		*calls++
+		if err := fails[cursor]; err != nil {
+			delete(fails, cursor)
+			return nil, err
+		}
+
+		end := cursor + 2
+		if end > len(items) {
+			end = len(items)
+		}
+
+		return &Page[int, int]{Items: items[cursor:end], Next: end, HasMore: end < len(items)}, nil
+	}
+}
+
+func TestPager_All(t *testing.T) {
+	var calls int
+	p := New(pages([]int{1, 2, 3, 4, 5}, nil, &calls))
+
+	items, err := Collect(p.All(context.Background()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(items, []int{1, 2, 3, 4, 5}) || calls != 3 || !p.Done() {
+		t.Fatalf("unexpected result: %v, calls %d, done %v", items, calls, p.Done())
+	}
+}
+
+func TestPager_Resume(t *testing.T) {
+	var (
+		calls int
+		items []int
+		boom  = errors.New("boom")
+		p     = New(pages([]int{1, 2, 3, 4, 5}, map[int]error{4: boom}, &calls))
+	)
+
+	for item, err := range p.All(context.Background()) {
+		if err != nil {
+			t.Fatal(err)
+		}
+		items = append(items, item)
+		if item == 3 {
+			break
+		}
+	}
+	if c := p.Cursor(); c.Page != 2 || c.Offset != 1 || c.Done {
+		t.Fatalf("unexpected cursor after early stop: %+v", c)
+	}
+
+	// 通过序列化后的游标续拉
+	b, _ := json.Marshal(p.Cursor())
+	var cursor Cursor[int]
+	if err := json.Unmarshal(b, &cursor); err != nil {
+		t.Fatal(err)
+	}
+	p = New(pages([]int{1, 2, 3, 4, 5}, map[int]error{4: boom}, &calls), cursor)
+
+	for item, err := range p.All(context.Background()) {
+		if err != nil {
+			if !errors.Is(err, boom) || !errors.Is(p.Err(), boom) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			break
+		}
+		items = append(items, item)
+	}
+	if c := p.Cursor(); c.Page != 4 || c.Offset != 0 {
+		t.Fatalf("unexpected cursor after error: %+v", c)
+	}
+
+	rest, err := Collect(p.All(context.Background()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if items = append(items, rest...); !reflect.DeepEqual(items, []int{1, 2, 3, 4, 5}) {
+		t.Fatalf("unexpected items: %v", items)
+	}
+}
+
+func TestPager_Context(t *testing.T) {
+	var calls int
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	p := New(pages([]int{1, 2, 3}, nil, &calls))
+
+	var items []int
+	for item, err := range p.All(ctx) {
+		if err != nil {
+			if !errors.Is(err, context.Canceled) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			break
+		}
+		items = append(items, item)
+		cancel()
+	}
+	if !reflect.DeepEqual(items, []int{1}) || p.Cursor().Offset != 1 || calls != 1 {
+		t.Fatalf("unexpected result: %v, cursor %+v, calls %d", items, p.Cursor(), calls)
+	}
+}
+
+func TestPager_Stalled(t *testing.T) {
+	p := New(func(ctx context.Context, cursor int) (*Page[int, int], error) {
+		return &Page[int, int]{Next: cursor, HasMore: true}, nil
+	})
+
+	if _, err := Collect(p.All(context.Background())); !errors.Is(err, ErrStalled) {
+		t.Fatalf("expected stalled error, got %v", err)
+	}
+}
@@ -8,9 +8,12 @@
 package private
 
 import (
+	"context"
+
 	"github.com/d60-Lab/tencent-im/internal/conv"
 	"github.com/d60-Lab/tencent-im/internal/core"
 	"github.com/d60-Lab/tencent-im/internal/types"
+	"github.com/d60-Lab/tencent-im/pagination"
 )
 
 const (
@@ -86,6 +89,13 @@ type API interface {
 	// https://cloud.tencent.com/document/product/269/42794
 	PullMessages(arg *PullMessagesArg, fn func(ret *FetchMessagesRet)) (err error)
 
+	// IterMessages 迭代单聊消息
+	// 本API是借助"查询单聊消息"API进行扩展实现
+	// 按时间从新到旧逐条迭代会话消息，可传入此前保存的游标续拉
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/42794
+	IterMessages(arg *PullMessagesArg, cursor ...pagination.Cursor[MessagesCursor]) *pagination.Pager[*MessageItem, MessagesCursor]
+
 	// RevokeMessage 撤回单聊消息
 	// 管理员撤回单聊消息。
 	// 该接口可以撤回所有单聊消息，包括客户端发出的单聊消息，由 REST API 单发 和 批量发 接口发出的单聊消息。
@@ -248,9 +258,14 @@ func (a *api) ImportMessage(message *Message) (err error) {
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/42794
 func (a *api) FetchMessages(arg *FetchMessagesArg) (ret *FetchMessagesRet, err error) {
+	return a.fetchMessages(context.Background(), arg)
+}
+
+// fetchMessages 查询单聊消息（上下文取消时中止请求）
+func (a *api) fetchMessages(ctx context.Context, arg *FetchMessagesArg) (ret *FetchMessagesRet, err error) {
 	resp := &fetchMessagesResp{}
 
-	if err = a.client.Post(service, commandFetchMessages, arg, resp); err != nil {
+	if err = a.client.PostContext(ctx, service, commandFetchMessages, arg, resp); err != nil {
 		return
 	}
 
@@ -306,6 +321,45 @@ func (a *api) PullMessages(arg *PullMessagesArg, fn func(ret *FetchMessagesRet))
 	return
 }
 
+// IterMessages 迭代单聊消息
+// 本API是借助"查询单聊消息"API进行扩展实现
+// 按时间从新到旧逐条迭代会话消息，可传入此前保存的游标续拉
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/42794
+func (a *api) IterMessages(arg *PullMessagesArg, cursor ...pagination.Cursor[MessagesCursor]) *pagination.Pager[*MessageItem, MessagesCursor] {
+	return pagination.New(func(ctx context.Context, c MessagesCursor) (page *pagination.Page[*MessageItem, MessagesCursor], err error) {
+		req := &FetchMessagesArg{
+			FromUserId: arg.FromUserId,
+			ToUserId:   arg.ToUserId,
+			MaxLimited: arg.MaxLimited,
+			MinTime:    arg.MinTime,
+			MaxTime:    arg.MaxTime,
+			LastMsgKey: c.LastMsgKey,
+		}
+
+		if c.MinTime != 0 {
+			req.MinTime = c.MinTime
+		}
+
+		if c.MaxTime != 0 {
+			req.MaxTime = c.MaxTime
+		}
+
+		ret, err := a.fetchMessages(ctx, req)
+		if err != nil {
+			return
+		}
+
+		page = &pagination.Page[*MessageItem, MessagesCursor]{
+			Items:   ret.List,
+			Next:    MessagesCursor{LastMsgKey: ret.LastMsgKey, MinTime: req.MinTime, MaxTime: ret.LastMsgTime},
+			HasMore: ret.HasMore,
+		}
+
+		return
+	}, cursor...)
+}
+
 // RevokeMessage 撤回单聊消息
 // 管理员撤回单聊消息。
 // 该接口可以撤回所有单聊消息，包括客户端发出的单聊消息，由 REST API 单发 和 批量发 接口发出的单聊消息。
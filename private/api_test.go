@@ -0,0 +1,67 @@
+/**
+ * @Author: d60-Lab
+ * @Date: 2026/10/19
+ * @Desc: 单聊消息包外使用单元测试
+ */
+
+package private_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/d60-Lab/tencent-im/internal/core"
+	"github.com/d60-Lab/tencent-im/private"
+)
+
+// recordClient 记录请求并应答成功的测试客户端
+type recordClient struct {
+	core.Client
+	command string
+	body    map[string]interface{}
+}
+
+func (c *recordClient) Post(serviceName string, command string, data interface{}, resp interface{}) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	c.command, c.body = command, make(map[string]interface{})
+	if err = json.Unmarshal(b, &c.body); err != nil {
+		return err
+	}
+
+	return json.Unmarshal([]byte(`{"ActionStatus":"OK","MsgKey":"k1","MsgTime":100}`), resp)
+}
+
+func TestAPI_SendRelayMessage(t *testing.T) {
+	message := private.NewMessage()
+	message.SetSender("user1")
+	message.AddReceivers("user2")
+	message.AddContent(&private.MsgRelayContent{
+		Title:          "Chat history",
+		MsgNum:         1,
+		CompatibleText: "Please upgrade to view chat history",
+		AbstractList:   []string{"user3: hello"},
+		MsgList: []*private.RelayMessage{{
+			FromUserId:   "user3",
+			MsgTimeStamp: 100,
+			MsgBody:      []*private.MsgBody{{MsgType: "TIMTextElem", MsgContent: private.MsgTextContent{Text: "hello"}}},
+		}},
+	})
+
+	client := &recordClient{}
+	ret, err := private.NewAPI(client).SendMessage(message)
+	if err != nil || ret.MsgKey != "k1" {
+		t.Fatalf("SendMessage() = %+v, %v", ret, err)
+	}
+
+	body := client.body["MsgBody"].([]interface{})
+	if len(body) != 1 || body[0].(map[string]interface{})["MsgType"] != "TIMRelayElem" {
+		t.Fatalf("unexpected message body: %v", body)
+	}
+	if content := body[0].(map[string]interface{})["MsgContent"].(map[string]interface{}); content["Title"] != "Chat history" || len(content["MsgList"].([]interface{})) != 1 {
+		t.Fatalf("unexpected relay content: %v", content)
+	}
+}
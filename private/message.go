@@ -22,7 +22,6 @@ type Message struct {
 	syncOtherMachine int             // 同步到其他器
 	timestamp        int64           // 消息时间戳，UNIX 时间戳（单位：秒）
 	seq              int             // 消息序列号
-	customData       interface{}     // 自定义数据
 	sendControls     map[string]bool // 发送消息控制
 	callbackControls map[string]bool // 禁用回调
 }
@@ -86,16 +85,6 @@ func (m *Message) GetTimestamp() int64 {
 	return m.timestamp
 }
 
-// SetCustomData 设置自定义数据
-func (m *Message) SetCustomData(data interface{}) {
-	m.customData = data
-}
-
-// GetCustomData 获取自定义数据
-func (m *Message) GetCustomData() interface{} {
-	return m.customData
-}
-
 // SetForbidBeforeSendMsgCallback 设置禁止发消息前回调
 func (m *Message) SetForbidBeforeSendMsgCallback() {
 	if m.callbackControls == nil {
@@ -166,6 +155,10 @@ func (m *Message) CheckError() (err error) {
 		return
 	}
 
+	if err = m.CheckOfflinePushArgError(); err != nil {
+		return
+	}
+
 	if err = m.checkReceiverArgError(); err != nil {
 		return
 	}
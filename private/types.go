@@ -134,6 +134,13 @@ type (
 		MaxTime    int64  `json:"MaxTime"`      // （必填）请求的消息时间范围的最大值
 	}
 
+	// MessagesCursor 迭代单聊消息的分页游标，记录续拉位置及请求的时间窗口
+	MessagesCursor struct {
+		LastMsgKey string `json:"last_msg_key"` // 上一页最后一条消息的标识，首页为空
+		MinTime    int64  `json:"min_time"`     // 请求的消息时间范围的最小值，为 0 时使用参数中的 MinTime
+		MaxTime    int64  `json:"max_time"`     // 下一页请求的消息时间范围的最大值，为 0 时使用参数中的 MaxTime
+	}
+
 	// 撤销消息（请求）
 	revokeMessageReq struct {
 		FromUserId string `json:"From_Account"` // （必填）消息发送方UserID
@@ -213,4 +220,7 @@ type (
 	MsgVideoContent    = types.MsgVideoContent
 	MsgCustomContent   = types.MsgCustomContent
 	MsgLocationContent = types.MsgLocationContent
+	MsgRelayContent    = types.MsgRelayContent
+	RelayMessage       = types.RelayMessage
+	MsgBody            = types.MsgBody
 )
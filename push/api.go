@@ -11,6 +11,7 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/d60-Lab/tencent-im/internal/conv"
 	"github.com/d60-Lab/tencent-im/internal/core"
 	"github.com/d60-Lab/tencent-im/internal/enum"
 	"github.com/d60-Lab/tencent-im/internal/types"
@@ -140,6 +141,7 @@ func (a *api) PushMessage(message *Message) (taskId string, err error) {
 	req.MsgBody = message.GetBody()
 	req.MsgRandom = message.GetRandom()
 	req.Condition = message.GetCondition()
+	req.CloudCustomData = conv.String(message.GetCustomData())
 
 	resp := &pushMessageResp{}
 
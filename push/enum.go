@@ -14,7 +14,7 @@ import (
 const (
 	// 推送标识
 	PushFlagYes = enum.PushFlagYes // 正常推送
-	PushFlagNo  = enum.PushFlagYes // 不离线推送
+	PushFlagNo  = enum.PushFlagNo  // 不离线推送
 
 	// 华为推送通知消息分类
 	HuaWeiImportanceLow    = enum.HuaWeiImportanceLow    // LOW类消息
@@ -35,4 +35,39 @@ const (
 	// IOS10的推送扩展开关
 	MutableContentNormal = enum.MutableContentNormal // 关闭iOS10的推送扩展
 	MutableContentEnable = enum.MutableContentEnable // 开启iOS10的推送扩展
+
+	// 荣耀推送通知消息分类
+	HonorImportanceLow    = enum.HonorImportanceLow    // LOW类消息
+	HonorImportanceNormal = enum.HonorImportanceNormal // NORMAL类消息
+
+	// OPPO通知栏消息提醒等级
+	OPPONotifyLevelBar        = enum.OPPONotifyLevelBar        // 通知栏
+	OPPONotifyLevelLockScreen = enum.OPPONotifyLevelLockScreen // 通知栏、锁屏
+	OPPONotifyLevelFull       = enum.OPPONotifyLevelFull       // 通知栏、锁屏、横幅、震动、铃声
+
+	// FCM推送消息类型
+	FCMPushTypeNotification = enum.FCMPushTypeNotification // 通知消息
+	FCMPushTypeData         = enum.FCMPushTypeData         // 数据消息
+
+	// IOS通知中断级别
+	InterruptionLevelPassive       = enum.InterruptionLevelPassive       // 静默展示，不点亮屏幕
+	InterruptionLevelActive        = enum.InterruptionLevelActive        // 默认级别
+	InterruptionLevelTimeSensitive = enum.InterruptionLevelTimeSensitive // 时效性通知，可突破专注模式
+	InterruptionLevelCritical      = enum.InterruptionLevelCritical      // 关键通知，需申请特殊权限
+
+	// 离线推送厂商通道
+	PushVendorHuaWei = enum.PushVendorHuaWei // 华为
+	PushVendorHonor  = enum.PushVendorHonor  // 荣耀
+	PushVendorXiaoMi = enum.PushVendorXiaoMi // 小米
+	PushVendorOPPO   = enum.PushVendorOPPO   // OPPO
+	PushVendorVIVO   = enum.PushVendorVIVO   // VIVO
+	PushVendorMeiZu  = enum.PushVendorMeiZu  // 魅族
+	PushVendorFCM    = enum.PushVendorFCM    // Google FCM
+	PushVendorTPNS   = enum.PushVendorTPNS   // 腾讯移动推送TPNS
+	PushVendorAPNs   = enum.PushVendorAPNs   // Apple APNs
+
+	// 离线推送优先级
+	PushPriorityLow    = enum.PushPriorityLow    // 低优先级，如运营类消息
+	PushPriorityNormal = enum.PushPriorityNormal // 普通优先级
+	PushPriorityHigh   = enum.PushPriorityHigh   // 高优先级，如即时通讯及时效性消息
 )
@@ -123,6 +123,10 @@ func (m *Message) checkError() (err error) {
 		return
 	}
 
+	if err = m.CheckOfflinePushArgError(); err != nil {
+		return
+	}
+
 	if err = m.checkConditionArgError(); err != nil {
 		return
 	}
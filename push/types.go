@@ -26,6 +26,7 @@ type (
 		MsgBody         []*types.MsgBody       `json:"MsgBody"`                   // （必填）消息内容
 		MsgLifeTime     int                    `json:"MsgLifeTime,omitempty"`     // （选填）消息离线存储时间，单位秒，最多保存7天（604800秒）。默认为0，表示不离线存储
 		OfflinePushInfo *types.OfflinePushInfo `json:"OfflinePushInfo,omitempty"` // （选填）离线推送信息配置
+		CloudCustomData string                 `json:"CloudCustomData,omitempty"` // （选填）消息自定义数据（云端保存，会发送到对端，程序卸载重装后还能拉取到）
 	}
 
 	// 推送（响应）
@@ -123,4 +124,7 @@ type (
 	MsgVideoContent    = types.MsgVideoContent
 	MsgCustomContent   = types.MsgCustomContent
 	MsgLocationContent = types.MsgLocationContent
+	MsgRelayContent    = types.MsgRelayContent
+	RelayMessage       = types.RelayMessage
+	MsgBody            = types.MsgBody
 )
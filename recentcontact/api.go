@@ -8,8 +8,11 @@
 package recentcontact
 
 import (
+	"context"
+
 	"github.com/d60-Lab/tencent-im/internal/core"
 	"github.com/d60-Lab/tencent-im/internal/types"
+	"github.com/d60-Lab/tencent-im/pagination"
 )
 
 const (
@@ -40,6 +43,13 @@ type API interface {
 	// https://cloud.tencent.com/document/product/269/62118
 	PullSessions(arg *PullSessionsArg, fn func(ret *FetchSessionsRet)) (err error)
 
+	// IterSessions 迭代会话列表
+	// 本API是借助"拉取会话列表"API进行扩展实现
+	// 逐个迭代会话，可传入此前保存的游标续拉
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/62118
+	IterSessions(arg *PullSessionsArg, cursor ...pagination.Cursor[SessionsCursor]) *pagination.Pager[*SessionItem, SessionsCursor]
+
 	// DeleteSession 删除单个会话
 	// 删除指定会话，支持同步清理漫游消息。
 	// 点击查看详细文档:
@@ -98,6 +108,11 @@ func NewAPI(client core.Client) API {
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/62118
 func (a *api) FetchSessions(arg *FetchSessionsArg) (ret *FetchSessionsRet, err error) {
+	return a.fetchSessions(context.Background(), arg)
+}
+
+// fetchSessions 拉取会话列表（上下文取消时中止请求）
+func (a *api) fetchSessions(ctx context.Context, arg *FetchSessionsArg) (ret *FetchSessionsRet, err error) {
 	req := &fetchSessionsReq{
 		UserId:        arg.UserId,
 		TimeStamp:     arg.TimeStamp,
@@ -120,7 +135,7 @@ func (a *api) FetchSessions(arg *FetchSessionsArg) (ret *FetchSessionsRet, err e
 
 	resp := &fetchSessionsResp{}
 
-	if err = a.client.Post(service, commandFetchSessions, req, resp); err != nil {
+	if err = a.client.PostContext(ctx, service, commandFetchSessions, req, resp); err != nil {
 		return
 	}
 
@@ -171,6 +186,42 @@ func (a *api) PullSessions(arg *PullSessionsArg, fn func(ret *FetchSessionsRet))
 	return
 }
 
+// IterSessions 迭代会话列表
+// 本API是借助"拉取会话列表"API进行扩展实现
+// 逐个迭代会话，可传入此前保存的游标续拉
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/62118
+func (a *api) IterSessions(arg *PullSessionsArg, cursor ...pagination.Cursor[SessionsCursor]) *pagination.Pager[*SessionItem, SessionsCursor] {
+	return pagination.New(func(ctx context.Context, c SessionsCursor) (page *pagination.Page[*SessionItem, SessionsCursor], err error) {
+		ret, err := a.fetchSessions(ctx, &FetchSessionsArg{
+			UserId:                  arg.UserId,
+			TimeStamp:               c.TimeStamp,
+			StartIndex:              c.StartIndex,
+			TopTimeStamp:            c.TopTimeStamp,
+			TopStartIndex:           c.TopStartIndex,
+			IsAllowTopSession:       arg.IsAllowTopSession,
+			IsReturnEmptySession:    arg.IsReturnEmptySession,
+			IsAllowTopSessionPaging: arg.IsAllowTopSessionPaging,
+		})
+		if err != nil {
+			return
+		}
+
+		page = &pagination.Page[*SessionItem, SessionsCursor]{
+			Items: ret.List,
+			Next: SessionsCursor{
+				TimeStamp:     ret.TimeStamp,
+				StartIndex:    ret.StartIndex,
+				TopTimeStamp:  ret.TopTimeStamp,
+				TopStartIndex: ret.TopStartIndex,
+			},
+			HasMore: ret.HasMore,
+		}
+
+		return
+	}, cursor...)
+}
+
 // DeleteSession 删除单个会话
 // 删除指定会话，支持同步清理漫游消息。
 // 点击查看详细文档:
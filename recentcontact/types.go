@@ -39,6 +39,14 @@ type FetchSessionsRet struct {
 	List          []*SessionItem // 会话对象数组
 }
 
+// SessionsCursor 迭代会话列表的分页游标
+type SessionsCursor struct {
+	TimeStamp     int `json:"timestamp"`       // 普通会话下一页拉取的起始时间
+	StartIndex    int `json:"start_index"`     // 普通会话下一页拉取的起始位置
+	TopTimeStamp  int `json:"top_timestamp"`   // 置顶会话下一页拉取的起始时间
+	TopStartIndex int `json:"top_start_index"` // 置顶会话下一页拉取的起始位置
+}
+
 // fetchSessionsReq 拉取会话列表（请求）
 type fetchSessionsReq struct {
 	UserId        string `json:"From_Account"`  // （必填）请求拉取该用户的会话列表
@@ -8,11 +8,13 @@
 package sns
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/d60-Lab/tencent-im/internal/core"
 	"github.com/d60-Lab/tencent-im/internal/enum"
 	"github.com/d60-Lab/tencent-im/internal/types"
+	"github.com/d60-Lab/tencent-im/pagination"
 )
 
 const (
@@ -145,6 +147,13 @@ type API interface {
 	// https://cloud.tencent.com/document/product/269/1647
 	PullFriends(userId string, fn func(ret *FetchFriendsRet)) (err error)
 
+	// IterFriends 迭代好友
+	// 本API是借助"拉取好友"API进行扩展实现
+	// 逐个迭代全量好友数据，可传入此前保存的游标续拉。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/1647
+	IterFriends(userId string, cursor ...pagination.Cursor[FriendsCursor]) *pagination.Pager[*Friend, FriendsCursor]
+
 	// AddBlacklist 添加黑名单
 	// 添加黑名单，支持批量添加黑名单。
 	// 点击查看详细文档:
@@ -170,6 +179,13 @@ type API interface {
 	// https://cloud.tencent.com/document/product/269/3722
 	PullBlacklist(userId string, maxLimited int, fn func(ret *FetchBlacklistRet)) (err error)
 
+	// IterBlacklist 迭代黑名单
+	// 本API是借助"拉取黑名单"API进行扩展实现
+	// 逐个迭代所有黑名单，可传入此前保存的游标续拉。
+	// 点击查看详细文档:
+	// https://cloud.tencent.com/document/product/269/3722
+	IterBlacklist(userId string, maxLimited int, cursor ...pagination.Cursor[BlacklistCursor]) *pagination.Pager[*Blacklist, BlacklistCursor]
+
 	// CheckBlacklist 校验黑名单
 	// 点击查看详细文档:
 	// https://cloud.tencent.com/document/product/269/3725
@@ -602,6 +618,11 @@ func (a *api) GetFriends(userId string, tagList []string, friendUserIds ...strin
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/1647
 func (a *api) FetchFriends(userId string, startIndex int, sequence ...int) (ret *FetchFriendsRet, err error) {
+	return a.fetchFriends(context.Background(), userId, startIndex, sequence...)
+}
+
+// fetchFriends 拉取好友（上下文取消时中止请求）
+func (a *api) fetchFriends(ctx context.Context, userId string, startIndex int, sequence ...int) (ret *FetchFriendsRet, err error) {
 	req := &fetchFriendsReq{UserId: userId, StartIndex: startIndex}
 	resp := &fetchFriendsResp{}
 
@@ -613,7 +634,7 @@ func (a *api) FetchFriends(userId string, startIndex int, sequence ...int) (ret
 		req.CustomSequence = sequence[1]
 	}
 
-	if err = a.client.Post(service, commandFetchFriend, req, resp); err != nil {
+	if err = a.client.PostContext(ctx, service, commandFetchFriend, req, resp); err != nil {
 		return
 	}
 
@@ -670,6 +691,32 @@ func (a *api) PullFriends(userId string, fn func(ret *FetchFriendsRet)) (err err
 	return
 }
 
+// IterFriends 迭代好友
+// 本API是借助"拉取好友"API进行扩展实现
+// 逐个迭代全量好友数据，可传入此前保存的游标续拉。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/1647
+func (a *api) IterFriends(userId string, cursor ...pagination.Cursor[FriendsCursor]) *pagination.Pager[*Friend, FriendsCursor] {
+	return pagination.New(func(ctx context.Context, c FriendsCursor) (page *pagination.Page[*Friend, FriendsCursor], err error) {
+		ret, err := a.fetchFriends(ctx, userId, c.StartIndex, c.StandardSequence, c.CustomSequence)
+		if err != nil {
+			return
+		}
+
+		page = &pagination.Page[*Friend, FriendsCursor]{
+			Items: ret.List,
+			Next: FriendsCursor{
+				StartIndex:       ret.StartIndex,
+				StandardSequence: ret.StandardSequence,
+				CustomSequence:   ret.CustomSequence,
+			},
+			HasMore: ret.HasMore,
+		}
+
+		return
+	}, cursor...)
+}
+
 // AddBlacklist 添加黑名单
 // 添加黑名单，支持批量添加黑名单。
 // 点击查看详细文档:
@@ -725,6 +772,11 @@ func (a *api) DeleteBlacklist(userId string, deletedUserIds ...string) (results
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/3722
 func (a *api) FetchBlacklist(userId string, maxLimited int, startIndexAndSequence ...int) (ret *FetchBlacklistRet, err error) {
+	return a.fetchBlacklist(context.Background(), userId, maxLimited, startIndexAndSequence...)
+}
+
+// fetchBlacklist 拉取黑名单（上下文取消时中止请求）
+func (a *api) fetchBlacklist(ctx context.Context, userId string, maxLimited int, startIndexAndSequence ...int) (ret *FetchBlacklistRet, err error) {
 	req := &fetchBlacklistReq{UserId: userId, MaxLimited: maxLimited}
 
 	if len(startIndexAndSequence) > 0 {
@@ -737,7 +789,7 @@ func (a *api) FetchBlacklist(userId string, maxLimited int, startIndexAndSequenc
 
 	resp := &fetchBlacklistResp{}
 
-	if err = a.client.Post(service, commandGetBlackList, req, resp); err != nil {
+	if err = a.client.PostContext(ctx, service, commandGetBlackList, req, resp); err != nil {
 		return
 	}
 
@@ -780,6 +832,28 @@ func (a *api) PullBlacklist(userId string, maxLimited int, fn func(ret *FetchBla
 	return
 }
 
+// IterBlacklist 迭代黑名单
+// 本API是借助"拉取黑名单"API进行扩展实现
+// 逐个迭代所有黑名单，可传入此前保存的游标续拉。
+// 点击查看详细文档:
+// https://cloud.tencent.com/document/product/269/3722
+func (a *api) IterBlacklist(userId string, maxLimited int, cursor ...pagination.Cursor[BlacklistCursor]) *pagination.Pager[*Blacklist, BlacklistCursor] {
+	return pagination.New(func(ctx context.Context, c BlacklistCursor) (page *pagination.Page[*Blacklist, BlacklistCursor], err error) {
+		ret, err := a.fetchBlacklist(ctx, userId, maxLimited, c.StartIndex, c.LastSequence)
+		if err != nil {
+			return
+		}
+
+		page = &pagination.Page[*Blacklist, BlacklistCursor]{
+			Items:   ret.List,
+			Next:    BlacklistCursor{StartIndex: ret.StartIndex, LastSequence: ret.StandardSequence},
+			HasMore: ret.HasMore,
+		}
+
+		return
+	}, cursor...)
+}
+
 // CheckBlacklist 校验黑名单
 // 点击查看详细文档:
 // https://cloud.tencent.com/document/product/269/3725
@@ -178,6 +178,13 @@ type (
 		List             []*Friend // 好友列表
 	}
 
+	// FriendsCursor 迭代好友的分页游标
+	FriendsCursor struct {
+		StartIndex       int `json:"start_index"`       // 下一页的起始位置
+		StandardSequence int `json:"standard_sequence"` // 上一页返回的标准排序
+		CustomSequence   int `json:"custom_sequence"`   // 上一页返回的自定义排序
+	}
+
 	// 添加黑名单（请求）
 	addBlacklistReq struct {
 		UserId         string   `json:"From_Account"` // （必填）请求为该 UserID 添加黑名单
@@ -229,6 +236,12 @@ type (
 		List             []*Blacklist // 黑名单列表
 	}
 
+	// BlacklistCursor 迭代黑名单的分页游标
+	BlacklistCursor struct {
+		StartIndex   int `json:"start_index"`   // 下一页的起始位置
+		LastSequence int `json:"last_sequence"` // 上一页返回的黑名单序列号
+	}
+
 	// Blacklist 黑名单
 	Blacklist struct {
 		UserId string `json:"To_Account"`        // 黑名单的 UserID